@@ -0,0 +1,220 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Verification of detached CMS SignedData with GOST R 34.10-2012
+// signatures and Streebog digests (RFC 5652, RFC 4490).
+package cms
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"hash"
+
+	"go.cypherpunks.ru/gogost/v5/gost3410"
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+)
+
+var (
+	OIDData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	OIDSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	OIDContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	OIDMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+)
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+type encapsulatedContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     asn1.RawValue `asn1:"optional,explicit,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+type signerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+// Digest algorithms with the point size of the key they are used with.
+var digestAlgorithms = []struct {
+	oid       asn1.ObjectIdentifier
+	pointSize int
+	newHash   func() hash.Hash
+}{
+	{gost3410.OIDtc26gost34112012256, 32, gost34112012256.New},
+	{gost3410.OIDtc26gost34112012512, 64, gost34112012512.New},
+}
+
+// Signature algorithm is identified either with signature or with
+// public key algorithm OID (RFC 4490 uses the latter).
+func signatureAlgorithmKnown(algo asn1.ObjectIdentifier, pointSize int) bool {
+	if pointSize == 64 {
+		return algo.Equal(gost3410.OIDtc26SignWithDigestGost341012512) ||
+			algo.Equal(gost3410.OIDtc26gost341012512)
+	}
+	return algo.Equal(gost3410.OIDtc26SignWithDigestGost341012256) ||
+		algo.Equal(gost3410.OIDtc26gost341012256)
+}
+
+var errDigestMismatch = errors.New("gogost/cms: message digest mismatch")
+
+func digest(newHash func() hash.Hash, data []byte) []byte {
+	h := newHash()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// Get signed data for the signer: either the content itself, or the
+// DER encoded signed attributes, that must contain content type and
+// content's digest.
+func signedContent(si *signerInfo, eContentType asn1.ObjectIdentifier, newHash func() hash.Hash, data []byte) ([]byte, error) {
+	if len(si.SignedAttrs.FullBytes) == 0 {
+		return data, nil
+	}
+	var attrs []attribute
+	// Signed attributes are signed with SET OF tag, not the implicit one
+	der := append([]byte{}, si.SignedAttrs.FullBytes...)
+	der[0] = 0x31
+	rest, err := asn1.UnmarshalWithParams(der, &attrs, "set")
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("gogost/cms: trailing data after signed attributes")
+	}
+	var contentTypeFound, digestFound bool
+	for _, attr := range attrs {
+		switch {
+		case attr.Type.Equal(OIDContentType):
+			if contentTypeFound || len(attr.Values) != 1 {
+				return nil, errors.New("gogost/cms: invalid content type attribute")
+			}
+			var ct asn1.ObjectIdentifier
+			if _, err = asn1.Unmarshal(attr.Values[0].FullBytes, &ct); err != nil {
+				return nil, err
+			}
+			if !ct.Equal(eContentType) {
+				return nil, errors.New("gogost/cms: content type attribute mismatch")
+			}
+			contentTypeFound = true
+		case attr.Type.Equal(OIDMessageDigest):
+			if digestFound || len(attr.Values) != 1 {
+				return nil, errors.New("gogost/cms: invalid message digest attribute")
+			}
+			var md []byte
+			if _, err = asn1.Unmarshal(attr.Values[0].FullBytes, &md); err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(md, digest(newHash, data)) {
+				return nil, errDigestMismatch
+			}
+			digestFound = true
+		}
+	}
+	if !contentTypeFound || !digestFound {
+		return nil, errors.New("gogost/cms: signed attributes lack content type or message digest")
+	}
+	return der, nil
+}
+
+// Verify detached DER encoded CMS SignedData over data. Signer
+// identifiers and certificates in SignedData are not used: signature
+// is valid if at least one of signer infos is verified with one of
+// roots public keys, whose curve size matches its Streebog digest
+// algorithm. Both 256- and 512-bit keys are supported. If signed
+// attributes are present, then they must have content type and message
+// digest attributes and the signature is made over them, otherwise over
+// the data itself. Digest is reversed before VerifyDigest, as RFC 4490
+// requires. Error is returned if structure is malformed or encapsulates
+// the content, false if no valid signature is found.
+func VerifyCMS(data []byte, cms []byte, roots []*gost3410.PublicKey) (bool, error) {
+	var ci contentInfo
+	rest, err := asn1.Unmarshal(cms, &ci)
+	if err != nil {
+		return false, err
+	}
+	if len(rest) != 0 {
+		return false, errors.New("gogost/cms: trailing data after ContentInfo")
+	}
+	if !ci.ContentType.Equal(OIDSignedData) {
+		return false, errors.New("gogost/cms: not a SignedData")
+	}
+	var sd signedData
+	rest, err = asn1.Unmarshal(ci.Content.Bytes, &sd)
+	if err != nil {
+		return false, err
+	}
+	if len(rest) != 0 {
+		return false, errors.New("gogost/cms: trailing data after SignedData")
+	}
+	if len(sd.EncapContentInfo.EContent.FullBytes) != 0 {
+		return false, errors.New("gogost/cms: SignedData is not detached")
+	}
+	for i := range sd.SignerInfos {
+		si := &sd.SignerInfos[i]
+		for _, known := range digestAlgorithms {
+			if !known.oid.Equal(si.DigestAlgorithm.Algorithm) {
+				continue
+			}
+			if !signatureAlgorithmKnown(si.SignatureAlgorithm.Algorithm, known.pointSize) {
+				break
+			}
+			signed, err := signedContent(si, sd.EncapContentInfo.EContentType, known.newHash, data)
+			if err == errDigestMismatch {
+				break
+			}
+			if err != nil {
+				return false, err
+			}
+			dgst := digest(known.newHash, signed)
+			for l, r := 0, len(dgst)-1; l < r; l, r = l+1, r-1 {
+				dgst[l], dgst[r] = dgst[r], dgst[l]
+			}
+			for _, pub := range roots {
+				if pub.C.PointSize() != known.pointSize {
+					continue
+				}
+				valid, err := pub.VerifyDigest(dgst, si.Signature)
+				if err == nil && valid {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
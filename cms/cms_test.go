@@ -0,0 +1,122 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cms
+
+import (
+	"crypto/rand"
+	"encoding/asn1"
+	"io/ioutil"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost3410"
+	"go.cypherpunks.ru/gogost/v5/x509"
+)
+
+func readFile(t *testing.T, path string) []byte {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// Get signer's public key from the certificate embedded in SignedData.
+func signerKey(t *testing.T, cms []byte) *gost3410.PublicKey {
+	var ci contentInfo
+	if _, err := asn1.Unmarshal(cms, &ci); err != nil {
+		t.Fatal(err)
+	}
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		t.Fatal(err)
+	}
+	var raw asn1.RawValue
+	if _, err := asn1.Unmarshal(sd.Certificates.Bytes, &raw); err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(raw.FullBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert.PublicKey.(*gost3410.PublicKey)
+}
+
+// Fixtures are detached SignedData with self-signed certificate of the
+// signer, made with x509.CreateCertificate: with signed attributes
+// (content type, signing time, message digest) and without them.
+// openssl cms -cmsout -print parses them. gnutls*.p7s are made by
+// GnuTLS 3.7.9 gnutls_pkcs7_sign with signed attributes and 256-bit
+// (paramSetB) and 512-bit (paramSetA) keys.
+func TestVerifyCMS(t *testing.T) {
+	data := readFile(t, "testdata/data.txt")
+	other, err := gost3410.GenPrivateKey(gost3410.CurveIdtc26gost341012256paramSetB(), rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	otherPub, err := other.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	for _, path := range []string{
+		"testdata/signed256.p7s",
+		"testdata/signed512.p7s",
+		"testdata/signed256-noattrs.p7s",
+		"testdata/gnutls256.p7s",
+		"testdata/gnutls512.p7s",
+	} {
+		cms := readFile(t, path)
+		pub := signerKey(t, cms)
+		valid, err := VerifyCMS(data, cms, []*gost3410.PublicKey{otherPub, pub})
+		if err != nil || !valid {
+			t.Fatal(path, err)
+		}
+		valid, err = VerifyCMS(data, cms, []*gost3410.PublicKey{otherPub})
+		if err != nil || valid {
+			t.Fatal(path, err)
+		}
+		tampered := append([]byte{}, data...)
+		tampered[0] ^= 0x01
+		valid, err = VerifyCMS(tampered, cms, []*gost3410.PublicKey{pub})
+		if err != nil || valid {
+			t.Fatal(path, err)
+		}
+		tampered = append([]byte{}, cms...)
+		tampered[len(tampered)-1] ^= 0x01
+		valid, err = VerifyCMS(data, tampered, []*gost3410.PublicKey{pub})
+		if err != nil || valid {
+			t.Fatal(path, err)
+		}
+		if _, err = VerifyCMS(data, append(cms, 0), []*gost3410.PublicKey{pub}); err == nil {
+			t.Fatal(path)
+		}
+		if _, err = VerifyCMS(data, cms[:len(cms)-1], []*gost3410.PublicKey{pub}); err == nil {
+			t.Fatal(path)
+		}
+	}
+}
+
+func TestVerifyCMSNotSignedData(t *testing.T) {
+	der, err := asn1.Marshal(contentInfo{
+		ContentType: OIDData,
+		Content:     asn1.RawValue{Tag: asn1.TagOctetString, Bytes: []byte("data")},
+	})
+	if err != nil {
+		t.FailNow()
+	}
+	if _, err = VerifyCMS(nil, der, nil); err == nil {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,122 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+
+	"go.cypherpunks.ru/gogost/v5/internal/ct"
+)
+
+// Encrypt-then-MAC AEAD made of CNT mode and MAC (imitovstavka).
+//
+// It is a library-defined construction, not a GOST standard, intended
+// for users who need AEAD with the legacy cipher; prefer MGM with
+// GOST R 34.12-2015 ciphers when possible. Different keys are used for
+// encryption and authentication. MAC is computed over
+// nonce || additionalData || ciphertext || len(additionalData) ||
+// len(ciphertext), lengths being 64-bit big-endian byte counts. Nonce
+// is CNT's 8-byte IV and must not be repeated under the same key.
+type EtM struct {
+	c      *Cipher
+	macKey []byte
+	sbox   *Sbox
+	size   int
+}
+
+// Create EtM AEAD. tagSize is the MAC size in bytes, between 4 and 8.
+func NewEtM(encKey, macKey []byte, sbox *Sbox, tagSize int) (cipher.AEAD, error) {
+	if len(encKey) != KeySize || len(macKey) != KeySize {
+		return nil, errors.New("gogost/gost28147: len(key) != 32")
+	}
+	if sbox == nil {
+		return nil, errors.New("gogost/gost28147: nil sbox")
+	}
+	if tagSize < 4 || tagSize > BlockSize {
+		return nil, errors.New("gogost/gost28147: invalid tag size")
+	}
+	if ct.Eq(encKey, macKey) {
+		return nil, errors.New("gogost/gost28147: encryption and MAC keys are equal")
+	}
+	k := make([]byte, KeySize)
+	copy(k, macKey)
+	return &EtM{c: NewCipher(encKey, sbox), macKey: k, sbox: sbox, size: tagSize}, nil
+}
+
+func (e *EtM) NonceSize() int {
+	return BlockSize
+}
+
+func (e *EtM) Overhead() int {
+	return e.size
+}
+
+func (e *EtM) validateNonce(nonce []byte) {
+	if len(nonce) != BlockSize {
+		panic("nonce length is not equal to blocksize")
+	}
+}
+
+func (e *EtM) tag(nonce, ct, ad []byte) []byte {
+	m, err := NewMAC(e.macKey, e.size, e.sbox)
+	if err != nil {
+		panic(err)
+	}
+	m.Write(nonce)
+	m.Write(ad)
+	m.Write(ct)
+	var lens [16]byte
+	binary.BigEndian.PutUint64(lens[:8], uint64(len(ad)))
+	binary.BigEndian.PutUint64(lens[8:], uint64(len(ct)))
+	m.Write(lens[:])
+	return m.Sum(nil)
+}
+
+func (e *EtM) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	e.validateNonce(nonce)
+	ret, out := sliceForAppend(dst, len(plaintext)+e.size)
+	e.c.NewCTR(nonce).XORKeyStream(out, plaintext)
+	copy(out[len(plaintext):], e.tag(nonce, out[:len(plaintext)], additionalData))
+	return ret
+}
+
+func (e *EtM) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	e.validateNonce(nonce)
+	if len(ciphertext) < e.size {
+		return nil, errors.New("gogost/gost28147: ciphertext is too short")
+	}
+	text := ciphertext[:len(ciphertext)-e.size]
+	if !ct.Eq(e.tag(nonce, text, additionalData), ciphertext[len(text):]) {
+		return nil, errors.New("gogost/gost28147: invalid authentication tag")
+	}
+	ret, out := sliceForAppend(dst, len(text))
+	e.c.NewCTR(nonce).XORKeyStream(out, text)
+	return ret, nil
+}
+
+// Taken from go/src/crypto/cipher/gcm.go
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
@@ -0,0 +1,89 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"bytes"
+	"testing"
+	"testing/quick"
+)
+
+func TestEtMSymmetric(t *testing.T) {
+	f := func(encKey, macKey [KeySize]byte, nonce [BlockSize]byte, pt, ad []byte) bool {
+		if encKey == macKey {
+			return true
+		}
+		aead, err := NewEtM(encKey[:], macKey[:], SboxDefault, 4)
+		if err != nil {
+			return false
+		}
+		sealed := aead.Seal(nil, nonce[:], pt, ad)
+		if len(sealed) != len(pt)+aead.Overhead() {
+			return false
+		}
+		opened, err := aead.Open(nil, nonce[:], sealed, ad)
+		return err == nil && bytes.Compare(opened, pt) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEtMTamper(t *testing.T) {
+	encKey := make([]byte, KeySize)
+	macKey := bytes.Repeat([]byte{0x01}, KeySize)
+	nonce := make([]byte, BlockSize)
+	aead, err := NewEtM(encKey, macKey, SboxDefault, BlockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt := []byte("some plaintext to be authenticated")
+	ad := []byte("header")
+	sealed := aead.Seal(nil, nonce, pt, ad)
+	for i := 0; i < len(sealed); i++ {
+		tampered := append([]byte{}, sealed...)
+		tampered[i] ^= 0x80
+		if _, err = aead.Open(nil, nonce, tampered, ad); err == nil {
+			t.Fatal(i)
+		}
+	}
+	if _, err = aead.Open(nil, nonce, sealed, []byte("headeR")); err == nil {
+		t.FailNow()
+	}
+	if _, err = aead.Open(nil, nonce, sealed, append(ad, pt[0])); err == nil {
+		t.FailNow()
+	}
+	nonce[0] = 1
+	if _, err = aead.Open(nil, nonce, sealed, ad); err == nil {
+		t.FailNow()
+	}
+	if _, err = aead.Open(nil, nonce, sealed[:3], ad); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestEtMKeys(t *testing.T) {
+	key := make([]byte, KeySize)
+	if _, err := NewEtM(key, key, SboxDefault, 4); err == nil {
+		t.FailNow()
+	}
+	if _, err := NewEtM(key, key[1:], SboxDefault, 4); err == nil {
+		t.FailNow()
+	}
+	if _, err := NewEtM(key, bytes.Repeat([]byte{1}, KeySize), SboxDefault, 3); err == nil {
+		t.FailNow()
+	}
+}
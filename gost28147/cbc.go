@@ -0,0 +1,94 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+type CBCEncrypter struct {
+	c  *Cipher
+	iv []byte
+}
+
+// CBC encrypter. Data must be padded to BlockSize, for example with
+// PadPKCS7 or PadZero.
+func (c *Cipher) NewCBCEncrypter(iv []byte) *CBCEncrypter {
+	if len(iv) != BlockSize {
+		panic("iv length is not equal to blocksize")
+	}
+	encrypter := CBCEncrypter{c: c, iv: make([]byte, BlockSize)}
+	copy(encrypter.iv, iv)
+	return &encrypter
+}
+
+func (e *CBCEncrypter) BlockSize() int {
+	return BlockSize
+}
+
+func (e *CBCEncrypter) CryptBlocks(dst, src []byte) {
+	if len(src)%BlockSize != 0 {
+		panic("src is not multiple of blocksize")
+	}
+	if len(dst) < len(src) {
+		panic("dst is too short")
+	}
+	var n int
+	for i := 0; i < len(src); i += BlockSize {
+		for n = 0; n < BlockSize; n++ {
+			e.iv[n] ^= src[i+n]
+		}
+		e.c.Encrypt(e.iv, e.iv)
+		copy(dst[i:i+BlockSize], e.iv)
+	}
+}
+
+type CBCDecrypter struct {
+	c    *Cipher
+	iv   []byte
+	prev []byte
+}
+
+func (c *Cipher) NewCBCDecrypter(iv []byte) *CBCDecrypter {
+	if len(iv) != BlockSize {
+		panic("iv length is not equal to blocksize")
+	}
+	decrypter := CBCDecrypter{
+		c:    c,
+		iv:   make([]byte, BlockSize),
+		prev: make([]byte, BlockSize),
+	}
+	copy(decrypter.iv, iv)
+	return &decrypter
+}
+
+func (d *CBCDecrypter) BlockSize() int {
+	return BlockSize
+}
+
+func (d *CBCDecrypter) CryptBlocks(dst, src []byte) {
+	if len(src)%BlockSize != 0 {
+		panic("src is not multiple of blocksize")
+	}
+	if len(dst) < len(src) {
+		panic("dst is too short")
+	}
+	var n int
+	for i := 0; i < len(src); i += BlockSize {
+		copy(d.prev, src[i:i+BlockSize])
+		d.c.Decrypt(dst[i:i+BlockSize], d.prev)
+		for n = 0; n < BlockSize; n++ {
+			dst[i+n] ^= d.iv[n]
+		}
+		d.iv, d.prev = d.prev, d.iv
+	}
+}
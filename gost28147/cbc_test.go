@@ -18,6 +18,7 @@ package gost28147
 import (
 	"bytes"
 	"crypto/cipher"
+	"encoding/hex"
 	"testing"
 	"testing/quick"
 )
@@ -39,3 +40,119 @@ func TestCBCCrypter(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestCBCAgainstStdlib(t *testing.T) {
+	f := func(key [KeySize]byte, iv [BlockSize]byte, pt []byte) bool {
+		c := NewCipher(key[:], SboxDefault)
+		pt = PadPKCS7(pt)
+		ct := make([]byte, len(pt))
+		c.NewCBCEncrypter(iv[:]).CryptBlocks(ct, pt)
+		ctStd := make([]byte, len(pt))
+		cipher.NewCBCEncrypter(c, iv[:]).CryptBlocks(ctStd, pt)
+		if bytes.Compare(ct, ctStd) != 0 {
+			return false
+		}
+		c.NewCBCDecrypter(iv[:]).CryptBlocks(ct, ct)
+		unpadded, err := UnpadPKCS7(ct)
+		if err != nil {
+			return false
+		}
+		return bytes.Compare(unpadded, pt[:len(unpadded)]) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCBCVector(t *testing.T) {
+	key := make([]byte, KeySize)
+	for i := 0; i < KeySize; i++ {
+		key[i] = byte(i)
+	}
+	iv := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	pt := []byte("GOST 28147-89 in CBC mode")
+	ct, _ := hex.DecodeString("95495fd2f89f97691502d8fcf839b525d3fe448c8354bcaeb2d0d077686def95")
+	c := NewCipher(key, &SboxIdGost2814789CryptoProAParamSet)
+	padded := PadPKCS7(append([]byte{}, pt...))
+	got := make([]byte, len(padded))
+	c.NewCBCEncrypter(iv).CryptBlocks(got, padded)
+	if bytes.Compare(got, ct) != 0 {
+		t.FailNow()
+	}
+	c.NewCBCDecrypter(iv).CryptBlocks(got, got)
+	got, err := UnpadPKCS7(got)
+	if err != nil || bytes.Compare(got, pt) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestCBCStreaming(t *testing.T) {
+	key := make([]byte, KeySize)
+	iv := make([]byte, BlockSize)
+	c := NewCipher(key, SboxDefault)
+	pt := make([]byte, 4*BlockSize)
+	for i := 0; i < len(pt); i++ {
+		pt[i] = byte(i)
+	}
+	ct := make([]byte, len(pt))
+	c.NewCBCEncrypter(iv).CryptBlocks(ct, pt)
+	e := c.NewCBCEncrypter(iv)
+	got := make([]byte, len(pt))
+	e.CryptBlocks(got[:BlockSize], pt[:BlockSize])
+	e.CryptBlocks(got[BlockSize:], pt[BlockSize:])
+	if bytes.Compare(got, ct) != 0 {
+		t.FailNow()
+	}
+	d := c.NewCBCDecrypter(iv)
+	d.CryptBlocks(got[:3*BlockSize], ct[:3*BlockSize])
+	d.CryptBlocks(got[3*BlockSize:], ct[3*BlockSize:])
+	if bytes.Compare(got, pt) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestCBCInvalidIV(t *testing.T) {
+	c := NewCipher(make([]byte, KeySize), SboxDefault)
+	defer func() {
+		if recover() == nil {
+			t.FailNow()
+		}
+	}()
+	c.NewCBCEncrypter(make([]byte, BlockSize-1))
+}
+
+func TestPadZero(t *testing.T) {
+	if len(PadZero(nil)) != 0 {
+		t.FailNow()
+	}
+	if bytes.Compare(PadZero([]byte{1, 2, 3}), []byte{1, 2, 3, 0, 0, 0, 0, 0}) != 0 {
+		t.FailNow()
+	}
+	full := make([]byte, BlockSize)
+	if len(PadZero(full)) != BlockSize {
+		t.FailNow()
+	}
+}
+
+func TestPadPKCS7(t *testing.T) {
+	if bytes.Compare(PadPKCS7(nil), bytes.Repeat([]byte{8}, BlockSize)) != 0 {
+		t.FailNow()
+	}
+	if bytes.Compare(
+		PadPKCS7([]byte{1, 2, 3}),
+		[]byte{1, 2, 3, 5, 5, 5, 5, 5},
+	) != 0 {
+		t.FailNow()
+	}
+	for _, bad := range [][]byte{
+		nil,
+		{1, 2, 3},
+		{1, 2, 3, 4, 5, 6, 7, 0},
+		{1, 2, 3, 4, 5, 6, 7, 9},
+		{1, 2, 3, 4, 5, 6, 2, 3},
+	} {
+		if _, err := UnpadPKCS7(bad); err == nil {
+			t.Fatal(bad)
+		}
+	}
+}
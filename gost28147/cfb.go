@@ -16,8 +16,10 @@
 package gost28147
 
 type CFBEncrypter struct {
-	c  *Cipher
-	iv []byte
+	c         *Cipher
+	iv        []byte
+	meshing   bool
+	processed int
 }
 
 func (c *Cipher) NewCFBEncrypter(iv []byte) *CFBEncrypter {
@@ -29,12 +31,25 @@ func (c *Cipher) NewCFBEncrypter(iv []byte) *CFBEncrypter {
 	return &encrypter
 }
 
+// CFB encrypter with CryptoPro key meshing (RFC 4357) made after each
+// MeshingInterval bytes.
+func (c *Cipher) NewCFBMeshingEncrypter(iv []byte) *CFBEncrypter {
+	encrypter := c.NewCFBEncrypter(iv)
+	encrypter.meshing = true
+	return encrypter
+}
+
 func (c *CFBEncrypter) XORKeyStream(dst, src []byte) {
 	var n int
 	i := 0
 MainLoop:
-	for {
+	for i*BlockSize < len(src) {
+		if c.meshing && c.processed == MeshingInterval {
+			c.c = c.c.mesh(c.iv)
+			c.processed = 0
+		}
 		c.c.Encrypt(c.iv, c.iv)
+		c.processed += BlockSize
 		for n = 0; n < BlockSize; n++ {
 			if i*BlockSize+n == len(src) {
 				break MainLoop
@@ -48,8 +63,10 @@ MainLoop:
 }
 
 type CFBDecrypter struct {
-	c  *Cipher
-	iv []byte
+	c         *Cipher
+	iv        []byte
+	meshing   bool
+	processed int
 }
 
 func (c *Cipher) NewCFBDecrypter(iv []byte) *CFBDecrypter {
@@ -61,12 +78,25 @@ func (c *Cipher) NewCFBDecrypter(iv []byte) *CFBDecrypter {
 	return &decrypter
 }
 
+// CFB decrypter with CryptoPro key meshing (RFC 4357) made after each
+// MeshingInterval bytes.
+func (c *Cipher) NewCFBMeshingDecrypter(iv []byte) *CFBDecrypter {
+	decrypter := c.NewCFBDecrypter(iv)
+	decrypter.meshing = true
+	return decrypter
+}
+
 func (c *CFBDecrypter) XORKeyStream(dst, src []byte) {
 	var n int
 	i := 0
 MainLoop:
-	for {
+	for i*BlockSize < len(src) {
+		if c.meshing && c.processed == MeshingInterval {
+			c.c = c.c.mesh(c.iv)
+			c.processed = 0
+		}
 		c.c.Encrypt(c.iv, c.iv)
+		c.processed += BlockSize
 		for n = 0; n < BlockSize; n++ {
 			if i*BlockSize+n == len(src) {
 				break MainLoop
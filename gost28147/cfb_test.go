@@ -18,6 +18,8 @@ package gost28147
 import (
 	"bytes"
 	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 	"testing/quick"
 )
@@ -79,3 +81,111 @@ func TestCFBInterface(t *testing.T) {
 	var _ cipher.Stream = c.NewCFBEncrypter(iv[:])
 	var _ cipher.Stream = c.NewCFBDecrypter(iv[:])
 }
+
+func TestCFBBlockBoundaryCalls(t *testing.T) {
+	var key [KeySize]byte
+	var iv [BlockSize]byte
+	c := NewCipher(key[:], SboxDefault)
+	pt := make([]byte, 3*BlockSize)
+	ct1 := make([]byte, len(pt))
+	c.NewCFBEncrypter(iv[:]).XORKeyStream(ct1, pt)
+	ct2 := make([]byte, len(pt))
+	fe := c.NewCFBEncrypter(iv[:])
+	fe.XORKeyStream(ct2[:BlockSize], pt[:BlockSize])
+	fe.XORKeyStream(ct2[BlockSize:], pt[BlockSize:])
+	if bytes.Compare(ct1, ct2) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestCFBMeshing(t *testing.T) {
+	key := make([]byte, KeySize)
+	for i := 0; i < KeySize; i++ {
+		key[i] = byte(i)
+	}
+	iv := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	c := NewCipher(key, &SboxIdGost2814789CryptoProAParamSet)
+	pt := make([]byte, 3*MeshingInterval+5)
+	for i := 0; i < len(pt); i++ {
+		pt[i] = byte(i)
+	}
+	ctPlain := make([]byte, len(pt))
+	c.NewCFBEncrypter(iv).XORKeyStream(ctPlain, pt)
+	ct := make([]byte, len(pt))
+	c.NewCFBMeshingEncrypter(iv).XORKeyStream(ct, pt)
+	if bytes.Compare(ct[:MeshingInterval], ctPlain[:MeshingInterval]) != 0 {
+		t.FailNow()
+	}
+	if bytes.Compare(ct[MeshingInterval:], ctPlain[MeshingInterval:]) == 0 {
+		t.FailNow()
+	}
+
+	// Manually meshed key and IV must continue the stream
+	meshedKey := make([]byte, KeySize)
+	c.NewECBDecrypter().CryptBlocks(meshedKey, meshingC[:])
+	meshedIV := make([]byte, BlockSize)
+	copy(meshedIV, ct[MeshingInterval-BlockSize:MeshingInterval])
+	meshed := NewCipher(meshedKey, &SboxIdGost2814789CryptoProAParamSet)
+	meshed.Encrypt(meshedIV, meshedIV)
+	tmp := make([]byte, MeshingInterval)
+	meshed.NewCFBEncrypter(meshedIV).XORKeyStream(tmp, pt[MeshingInterval:2*MeshingInterval])
+	if bytes.Compare(tmp, ct[MeshingInterval:2*MeshingInterval]) != 0 {
+		t.FailNow()
+	}
+
+	fd := c.NewCFBMeshingDecrypter(iv)
+	pt2 := make([]byte, len(ct))
+	for i := 0; i < len(ct); i += 3 * BlockSize {
+		end := i + 3*BlockSize
+		if end > len(ct) {
+			end = len(ct)
+		}
+		fd.XORKeyStream(pt2[i:end], ct[i:end])
+	}
+	if bytes.Compare(pt2, pt) != 0 {
+		t.FailNow()
+	}
+}
+
+// Ciphertext made by GnuTLS 3.7.9 GOST28147-CPA-CFB cipher, that uses
+// CryptoPro key meshing, with 00..1F key, 01..08 IV and 00,01,02...
+// plaintext of 3077 bytes: parts around meshing points and SHA-256 of
+// the whole ciphertext.
+func TestCFBMeshingGnuTLS(t *testing.T) {
+	key := make([]byte, KeySize)
+	for i := 0; i < KeySize; i++ {
+		key[i] = byte(i)
+	}
+	iv := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	pt := make([]byte, 3*MeshingInterval+5)
+	for i := 0; i < len(pt); i++ {
+		pt[i] = byte(i)
+	}
+	c := NewCipher(key, &SboxIdGost2814789CryptoProAParamSet)
+	ct := make([]byte, len(pt))
+	c.NewCFBMeshingEncrypter(iv).XORKeyStream(ct, pt)
+	for _, v := range []struct {
+		offset   int
+		expected string
+	}{
+		{0, "27cb977c6023a7e32c33f8eaa09940e7"},
+		{1016, "0773e495e6d5da6c3f3082c9026b69217ae8cfeaaf53cd4b"},
+		{2040, "a226705c99665355ea2092b4ed153e7cef39f63250196188"},
+		{3064, "1828104c7b746ab507d15956f0"},
+	} {
+		expected, _ := hex.DecodeString(v.expected)
+		if bytes.Compare(ct[v.offset:v.offset+len(expected)], expected) != 0 {
+			t.Fatal("ciphertext differs at", v.offset)
+		}
+	}
+	sum := sha256.Sum256(ct)
+	expected, _ := hex.DecodeString("d57bc839533bcb3be5b70b123581ef54f871d4cf9aa9b4f8b625c25c2788a4ae")
+	if bytes.Compare(sum[:], expected) != 0 {
+		t.FailNow()
+	}
+	pt2 := make([]byte, len(ct))
+	c.NewCFBMeshingDecrypter(iv).XORKeyStream(pt2, ct)
+	if bytes.Compare(pt2, pt) != 0 {
+		t.FailNow()
+	}
+}
@@ -13,7 +13,7 @@
 // You should have received a copy of the GNU General Public License
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
-// GOST 28147-89 block cipher with ECB, CFB, CTR, MAC modes of operation.
+// GOST 28147-89 block cipher with ECB, CFB, CTR, OFB, MAC modes of operation.
 // RFC 5830.
 package gost28147
 
@@ -51,17 +51,27 @@ var (
 	})
 )
 
+// Key schedule and S-box lookup tables are computed once during the
+// cipher creation, so encryption and decryption are table lookups only.
 type Cipher struct {
 	key  [KeySize]byte
 	sbox *Sbox
+	t    *sboxTables
 	x    [8]nv
 }
 
+// Create the cipher with the given 32-byte key and S-box. It panics if
+// key has invalid size or S-box is nil. Key and blocks are read as
+// little-endian 32-bit words (WordOrderLE), use NewCipherOrder for
+// another convention.
 func NewCipher(key []byte, sbox *Sbox) *Cipher {
 	if len(key) != KeySize {
 		panic("invalid key size")
 	}
-	c := Cipher{sbox: sbox}
+	if sbox == nil {
+		panic("nil sbox")
+	}
+	c := Cipher{sbox: sbox, t: sbox.tables()}
 	copy(c.key[:], key)
 	c.x = [8]nv{
 		nv(key[0]) | nv(key[1])<<8 | nv(key[2])<<16 | nv(key[3])<<24,
@@ -100,8 +110,11 @@ func nvs2block(n1, n2 nv, b []byte) {
 }
 
 func (c *Cipher) xcrypt(seq Seq, n1, n2 nv) (nv, nv) {
+	t := c.t
+	var n nv
 	for _, i := range seq {
-		n1, n2 = c.sbox.k(n1+c.x[i]).shift11()^n2, n1
+		n = n1 + c.x[i]
+		n1, n2 = t[0][n&0xFF]^t[1][(n>>8)&0xFF]^t[2][(n>>16)&0xFF]^t[3][n>>24]^n2, n1
 	}
 	return n1, n2
 }
@@ -16,6 +16,7 @@
 package gost28147
 
 import (
+	"bytes"
 	"crypto/cipher"
 	"crypto/rand"
 	"testing"
@@ -25,6 +26,43 @@ func TestCipherInterface(t *testing.T) {
 	var _ cipher.Block = NewCipher(make([]byte, KeySize), SboxDefault)
 }
 
+// GOST R 34.12-2015 test vector, that is 28147-89 with
+// id-tc26-gost-28147-param-Z S-box and reversed byte order
+func TestCipherParamZVector(t *testing.T) {
+	key := []byte{
+		0xcc, 0xdd, 0xee, 0xff, 0x88, 0x99, 0xaa, 0xbb,
+		0x44, 0x55, 0x66, 0x77, 0x00, 0x11, 0x22, 0x33,
+		0xf3, 0xf2, 0xf1, 0xf0, 0xf7, 0xf6, 0xf5, 0xf4,
+		0xfb, 0xfa, 0xf9, 0xf8, 0xff, 0xfe, 0xfd, 0xfc,
+	}
+	pt := []byte{0x10, 0x32, 0x54, 0x76, 0x98, 0xba, 0xdc, 0xfe}
+	ct := []byte{0x3d, 0xca, 0xd8, 0xc2, 0xe5, 0x01, 0xe9, 0x4e}
+	c := NewCipher(key, &SboxIdtc26gost28147paramZ)
+	dst := make([]byte, BlockSize)
+	c.Encrypt(dst, pt)
+	if bytes.Compare(dst, ct) != 0 {
+		t.FailNow()
+	}
+	c.Decrypt(dst, dst)
+	if bytes.Compare(dst, pt) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestCipherInvalidParams(t *testing.T) {
+	f := func(key []byte, sbox *Sbox) {
+		defer func() {
+			if recover() == nil {
+				t.FailNow()
+			}
+		}()
+		NewCipher(key, sbox)
+	}
+	f(make([]byte, KeySize-1), SboxDefault)
+	f(make([]byte, KeySize+1), SboxDefault)
+	f(make([]byte, KeySize), nil)
+}
+
 func BenchmarkCipher(b *testing.B) {
 	var key [KeySize]byte
 	rand.Read(key[:])
@@ -37,3 +75,25 @@ func BenchmarkCipher(b *testing.B) {
 		c.Encrypt(dst, src)
 	}
 }
+
+func BenchmarkCipher1MB(b *testing.B) {
+	var key [KeySize]byte
+	rand.Read(key[:])
+	buf := make([]byte, 1<<20)
+	rand.Read(buf)
+	e := NewCipher(key[:], SboxDefault).NewECBEncrypter()
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.CryptBlocks(buf, buf)
+	}
+}
+
+func BenchmarkNewCipher(b *testing.B) {
+	var key [KeySize]byte
+	rand.Read(key[:])
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewCipher(key[:], SboxDefault)
+	}
+}
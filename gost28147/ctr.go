@@ -16,9 +16,11 @@
 package gost28147
 
 type CTR struct {
-	c  *Cipher
-	n1 nv
-	n2 nv
+	c         *Cipher
+	n1        nv
+	n2        nv
+	meshing   bool
+	processed int
 }
 
 func (c *Cipher) NewCTR(iv []byte) *CTR {
@@ -27,7 +29,15 @@ func (c *Cipher) NewCTR(iv []byte) *CTR {
 	}
 	n1, n2 := block2nvs(iv)
 	n2, n1 = c.xcrypt(SeqEncrypt, n1, n2)
-	return &CTR{c, n1, n2}
+	return &CTR{c: c, n1: n1, n2: n2}
+}
+
+// CNT mode with CryptoPro key meshing (RFC 4357) made after each
+// MeshingInterval bytes. Counter's state is meshed as IV.
+func (c *Cipher) NewCTRMeshing(iv []byte) *CTR {
+	ctr := c.NewCTR(iv)
+	ctr.meshing = true
+	return ctr
 }
 
 func (c *CTR) XORKeyStream(dst, src []byte) {
@@ -37,14 +47,23 @@ func (c *CTR) XORKeyStream(dst, src []byte) {
 	i := 0
 	var n int
 MainLoop:
-	for {
+	for i*BlockSize < len(src) {
+		if c.meshing && c.processed == MeshingInterval {
+			nvs2block(c.n2, c.n1, block)
+			c.c = c.c.mesh(block)
+			c.n1, c.n2 = block2nvs(block)
+			c.processed = 0
+		}
 		c.n1 += 0x01010101 // C2
+		// C1 is added modulo 2^32-1: carry out of 32 bits is added back
+		n2 := c.n2
 		c.n2 += 0x01010104 // C1
-		if c.n2 >= 1<<32-1 {
-			c.n2 -= 1<<32 - 1
+		if c.n2 < n2 {
+			c.n2++
 		}
 		n1t, n2t = c.c.xcrypt(SeqEncrypt, c.n1, c.n2)
 		nvs2block(n1t, n2t, block)
+		c.processed += BlockSize
 		for n = 0; n < BlockSize; n++ {
 			if i*BlockSize+n == len(src) {
 				break MainLoop
@@ -19,6 +19,8 @@ import (
 	"bytes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 	"testing/quick"
 )
@@ -194,3 +196,115 @@ func BenchmarkCTR(b *testing.B) {
 		ctr.XORKeyStream(dst, src)
 	}
 }
+
+func TestCTRBlockBoundaryCalls(t *testing.T) {
+	key := make([]byte, KeySize)
+	rand.Read(key)
+	iv := make([]byte, BlockSize)
+	rand.Read(iv)
+	c := NewCipher(key, SboxDefault)
+	pt := make([]byte, 5*BlockSize+3)
+	rand.Read(pt)
+	ct := make([]byte, len(pt))
+	c.NewCTR(iv).XORKeyStream(ct, pt)
+	ctr := c.NewCTR(iv)
+	ct2 := make([]byte, len(pt))
+	ctr.XORKeyStream(ct2[:2*BlockSize], pt[:2*BlockSize])
+	ctr.XORKeyStream(ct2[2*BlockSize:], pt[2*BlockSize:])
+	if bytes.Compare(ct, ct2) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestCTRMeshing(t *testing.T) {
+	key := make([]byte, KeySize)
+	for i := 0; i < KeySize; i++ {
+		key[i] = byte(i)
+	}
+	iv := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	c := NewCipher(key, &SboxIdGost2814789CryptoProAParamSet)
+	pt := make([]byte, 3*MeshingInterval+5)
+	for i := 0; i < len(pt); i++ {
+		pt[i] = byte(i)
+	}
+	ctPlain := make([]byte, len(pt))
+	c.NewCTR(iv).XORKeyStream(ctPlain, pt)
+	ctr := c.NewCTRMeshing(iv)
+	ct := make([]byte, len(pt))
+	ctr.XORKeyStream(ct[:MeshingInterval], pt[:MeshingInterval])
+	if bytes.Compare(ct[:MeshingInterval], ctPlain[:MeshingInterval]) != 0 {
+		t.FailNow()
+	}
+
+	// Manually meshed key and counter must continue the stream
+	meshedKey := make([]byte, KeySize)
+	c.NewECBDecrypter().CryptBlocks(meshedKey, meshingC[:])
+	meshed := NewCipher(meshedKey, &SboxIdGost2814789CryptoProAParamSet)
+	state := make([]byte, BlockSize)
+	nvs2block(ctr.n2, ctr.n1, state)
+	meshed.Encrypt(state, state)
+	n1, n2 := block2nvs(state)
+	manual := &CTR{c: meshed, n1: n1, n2: n2}
+	tmp := make([]byte, MeshingInterval)
+	manual.XORKeyStream(tmp, pt[MeshingInterval:2*MeshingInterval])
+
+	ctr.XORKeyStream(ct[MeshingInterval:], pt[MeshingInterval:])
+	if bytes.Compare(tmp, ct[MeshingInterval:2*MeshingInterval]) != 0 {
+		t.FailNow()
+	}
+	if bytes.Compare(ct[MeshingInterval:], ctPlain[MeshingInterval:]) == 0 {
+		t.FailNow()
+	}
+
+	ctr = c.NewCTRMeshing(iv)
+	pt2 := make([]byte, len(ct))
+	for i := 0; i < len(ct); i += 3 * BlockSize {
+		end := i + 3*BlockSize
+		if end > len(ct) {
+			end = len(ct)
+		}
+		ctr.XORKeyStream(pt2[i:end], ct[i:end])
+	}
+	if bytes.Compare(pt2, pt) != 0 {
+		t.FailNow()
+	}
+}
+
+// Ciphertext made by GnuTLS 3.7.9 GOST28147-TC26Z-CNT cipher, that uses
+// CryptoPro key meshing, with 00..1F key, 01..08 IV and 00,01,02...
+// plaintext of 3077 bytes: parts around meshing points and SHA-256 of
+// the whole ciphertext. N4 counter's addition overflows 32 bits at the
+// 52nd block.
+func TestCTRMeshingGnuTLS(t *testing.T) {
+	key := make([]byte, KeySize)
+	for i := 0; i < KeySize; i++ {
+		key[i] = byte(i)
+	}
+	iv := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	pt := make([]byte, 3*MeshingInterval+5)
+	for i := 0; i < len(pt); i++ {
+		pt[i] = byte(i)
+	}
+	c := NewCipher(key, &SboxIdtc26gost28147paramZ)
+	ct := make([]byte, len(pt))
+	c.NewCTRMeshing(iv).XORKeyStream(ct, pt)
+	for _, v := range []struct {
+		offset   int
+		expected string
+	}{
+		{0, "9507ea65d01c3a0c838d1ab2bfb4e1a5"},
+		{1016, "c89205d527cfd92a811015291d631b44dcd824b80ea05850"},
+		{2040, "a676dfab68e24298c62aeb102415b86a93e7c35d92dc279b"},
+		{3064, "0bac16e352d58c5831487a241b"},
+	} {
+		expected, _ := hex.DecodeString(v.expected)
+		if bytes.Compare(ct[v.offset:v.offset+len(expected)], expected) != 0 {
+			t.Fatal("ciphertext differs at", v.offset)
+		}
+	}
+	sum := sha256.Sum256(ct)
+	expected, _ := hex.DecodeString("9cb78ed534f3d86107d476dd99291200ca12f59cc7332da6cca18cd9235efd5b")
+	if bytes.Compare(sum[:], expected) != 0 {
+		t.FailNow()
+	}
+}
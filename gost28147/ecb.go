@@ -15,6 +15,11 @@
 
 package gost28147
 
+// ECB mode encrypts every block independently, so equal plaintext
+// blocks give equal ciphertext ones and the data structure leaks. It
+// must not be used for anything except of encrypting uniformly random
+// data, like keys in KeyWrap (RFC 4357 6.1) do. Both encrypter and
+// decrypter satisfy cipher.BlockMode and allow in place operation.
 type ECBEncrypter struct {
 	c *Cipher
 }
@@ -24,12 +29,25 @@ func (c *Cipher) NewECBEncrypter() *ECBEncrypter {
 	return &e
 }
 
-func (e *ECBEncrypter) CryptBlocks(dst, src []byte) {
+func (c *Cipher) ecb(seq Seq, dst, src []byte) {
+	if len(src)%BlockSize != 0 {
+		panic("src is not multiple of blocksize")
+	}
+	if len(dst) < len(src) {
+		panic("dst is too short")
+	}
+	var n1, n2 nv
 	for i := 0; i < len(src); i += BlockSize {
-		e.c.Encrypt(dst[i:i+BlockSize], src[i:i+BlockSize])
+		n1, n2 = block2nvs(src[i : i+BlockSize])
+		n1, n2 = c.xcrypt(seq, n1, n2)
+		nvs2block(n1, n2, dst[i:i+BlockSize])
 	}
 }
 
+func (e *ECBEncrypter) CryptBlocks(dst, src []byte) {
+	e.c.ecb(SeqEncrypt, dst, src)
+}
+
 func (e *ECBEncrypter) BlockSize() int {
 	return e.c.BlockSize()
 }
@@ -44,9 +62,7 @@ func (c *Cipher) NewECBDecrypter() *ECBDecrypter {
 }
 
 func (e *ECBDecrypter) CryptBlocks(dst, src []byte) {
-	for i := 0; i < len(src); i += BlockSize {
-		e.c.Decrypt(dst[i:i+BlockSize], src[i:i+BlockSize])
-	}
+	e.c.ecb(SeqDecrypt, dst, src)
 }
 
 func (e *ECBDecrypter) BlockSize() int {
@@ -270,3 +270,45 @@ func TestECBInterface(t *testing.T) {
 	var _ cipher.BlockMode = c.NewECBEncrypter()
 	var _ cipher.BlockMode = c.NewECBDecrypter()
 }
+
+func TestECBInvalidLength(t *testing.T) {
+	c := NewCipher(make([]byte, KeySize), SboxDefault)
+	for _, mode := range []cipher.BlockMode{
+		c.NewECBEncrypter(),
+		c.NewECBDecrypter(),
+	} {
+		for _, lens := range [][2]int{{BlockSize + 1, BlockSize + 1}, {BlockSize, 2 * BlockSize}} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatal("did not panic", lens)
+					}
+				}()
+				mode.CryptBlocks(make([]byte, lens[0]), make([]byte, lens[1]))
+			}()
+		}
+		mode.CryptBlocks(nil, nil)
+	}
+}
+
+func TestECBInPlace(t *testing.T) {
+	c := NewCipher(make([]byte, KeySize), SboxDefault)
+	src := make([]byte, 4*BlockSize)
+	for i := 0; i < len(src); i++ {
+		src[i] = byte(i)
+	}
+	expected := make([]byte, len(src))
+	for i := 0; i < len(src); i += BlockSize {
+		c.Encrypt(expected[i:], src[i:])
+	}
+	buf := make([]byte, len(src))
+	copy(buf, src)
+	c.NewECBEncrypter().CryptBlocks(buf, buf)
+	if bytes.Compare(buf, expected) != 0 {
+		t.Fatal("in place encryption differs")
+	}
+	c.NewECBDecrypter().CryptBlocks(buf, buf)
+	if bytes.Compare(buf, src) != 0 {
+		t.Fatal("in place decryption differs")
+	}
+}
@@ -0,0 +1,141 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"errors"
+
+	"go.cypherpunks.ru/gogost/v5/internal/ct"
+)
+
+// Size of CryptoPro wrapped key: encrypted CEK with 4-byte MAC.
+const WrappedKeySize = KeySize + 4
+
+// CryptoPro KEK diversification (RFC 4357 6.5). 8-byte UKM is used to
+// derive new KEK with eight CFB encryptions of the key under itself.
+func diversify(kek, ukm []byte, sbox *Sbox) []byte {
+	out := make([]byte, KeySize)
+	copy(out, kek)
+	iv := make([]byte, BlockSize)
+	for i := 0; i < 8; i++ {
+		var s1, s2 nv
+		for j := 0; j < 8; j++ {
+			k := nv(out[j*4]) | nv(out[j*4+1])<<8 |
+				nv(out[j*4+2])<<16 | nv(out[j*4+3])<<24
+			if (ukm[i]>>uint(j))&1 == 1 {
+				s1 += k
+			} else {
+				s2 += k
+			}
+		}
+		iv[0], iv[1], iv[2], iv[3] = byte(s1), byte(s1>>8), byte(s1>>16), byte(s1>>24)
+		iv[4], iv[5], iv[6], iv[7] = byte(s2), byte(s2>>8), byte(s2>>16), byte(s2>>24)
+		NewCipher(out, sbox).NewCFBEncrypter(iv).XORKeyStream(out, out)
+	}
+	return out
+}
+
+// CryptoPro KEK diversification (RFC 4357 6.5) KDF, the one
+// KeyWrapCryptoPro uses with SboxDefault. KEK is 32 bytes, UKM is 8.
+func KEKDiversify(kek, ukm []byte, sbox *Sbox) ([]byte, error) {
+	if err := keyWrapCheck(kek, ukm); err != nil {
+		return nil, err
+	}
+	if sbox == nil {
+		return nil, errors.New("gogost/gost28147: nil sbox")
+	}
+	return diversify(kek, ukm, sbox), nil
+}
+
+func keyWrapCheck(kek, ukm []byte) error {
+	if len(kek) != KeySize {
+		return errors.New("gogost/gost28147: len(kek) != 32")
+	}
+	if len(ukm) != BlockSize {
+		return errors.New("gogost/gost28147: len(ukm) != 8")
+	}
+	return nil
+}
+
+func keyWrap(kek, ukm, cek []byte) ([]byte, error) {
+	if len(cek) != KeySize {
+		return nil, errors.New("gogost/gost28147: len(cek) != 32")
+	}
+	c := NewCipher(kek, SboxDefault)
+	mac, err := c.NewMAC(4, ukm)
+	if err != nil {
+		return nil, err
+	}
+	mac.Write(cek)
+	wrapped := make([]byte, KeySize, WrappedKeySize)
+	c.NewECBEncrypter().CryptBlocks(wrapped, cek)
+	return mac.Sum(wrapped), nil
+}
+
+func keyUnwrap(kek, ukm, wrapped []byte) ([]byte, error) {
+	if len(wrapped) != WrappedKeySize {
+		return nil, errors.New("gogost/gost28147: invalid wrapped key length")
+	}
+	c := NewCipher(kek, SboxDefault)
+	cek := make([]byte, KeySize)
+	c.NewECBDecrypter().CryptBlocks(cek, wrapped[:KeySize])
+	mac, err := c.NewMAC(4, ukm)
+	if err != nil {
+		return nil, err
+	}
+	mac.Write(cek)
+	if !ct.Eq(mac.Sum(nil), wrapped[KeySize:]) {
+		return nil, errors.New("gogost/gost28147: invalid wrapped key MAC")
+	}
+	return cek, nil
+}
+
+// GOST 28147-89 key wrapping (RFC 4357 6.1) with SboxDefault. 32-byte
+// CEK is encrypted in ECB mode with KEK, MAC of CEK with 8-byte UKM as
+// IV is appended: CEK_ENC || CEK_MAC.
+func KeyWrap(kek, ukm, cek []byte) ([]byte, error) {
+	if err := keyWrapCheck(kek, ukm); err != nil {
+		return nil, err
+	}
+	return keyWrap(kek, ukm, cek)
+}
+
+// Unwrap key wrapped with KeyWrap. Error is returned if MAC does not
+// match.
+func KeyUnwrap(kek, ukm, wrapped []byte) ([]byte, error) {
+	if err := keyWrapCheck(kek, ukm); err != nil {
+		return nil, err
+	}
+	return keyUnwrap(kek, ukm, wrapped)
+}
+
+// CryptoPro key wrapping (RFC 4357 6.3) with SboxDefault. It is KeyWrap
+// with KEK diversified with UKM first.
+func KeyWrapCryptoPro(kek, ukm, cek []byte) ([]byte, error) {
+	if err := keyWrapCheck(kek, ukm); err != nil {
+		return nil, err
+	}
+	return keyWrap(diversify(kek, ukm, SboxDefault), ukm, cek)
+}
+
+// Unwrap key wrapped with KeyWrapCryptoPro. Error is returned if MAC
+// does not match.
+func KeyUnwrapCryptoPro(kek, ukm, wrapped []byte) ([]byte, error) {
+	if err := keyWrapCheck(kek, ukm); err != nil {
+		return nil, err
+	}
+	return keyUnwrap(diversify(kek, ukm, SboxDefault), ukm, wrapped)
+}
@@ -0,0 +1,166 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"testing/quick"
+)
+
+func TestKeyWrapCryptoProSymmetric(t *testing.T) {
+	f := func(kek, cek [KeySize]byte, ukm [BlockSize]byte) bool {
+		wrapped, err := KeyWrapCryptoPro(kek[:], ukm[:], cek[:])
+		if err != nil || len(wrapped) != WrappedKeySize {
+			return false
+		}
+		got, err := KeyUnwrapCryptoPro(kek[:], ukm[:], wrapped)
+		return err == nil && bytes.Compare(got, cek[:]) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// Vector produced by GnuTLS 3.7.9 _gnutls_gost28147_key_wrap_cryptopro
+// with CryptoPro-A parameters.
+func TestKeyWrapCryptoProGnuTLS(t *testing.T) {
+	kek, _ := hex.DecodeString("d4383e1d73dfc344cd7fbe49565b376eb197298d059116e5043dbc125c5cad2f")
+	ukm, _ := hex.DecodeString("a0a1a2a3a4a5a6a7")
+	cek, _ := hex.DecodeString("303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f")
+	expected, _ := hex.DecodeString("52360c15bb8e5c826f9cb8c0dd3acbdf203ee1b0cc323dd934c24dc0830760b565d4f6ff")
+	wrapped, err := KeyWrapCryptoPro(kek, ukm, cek)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(wrapped, expected) != 0 {
+		t.FailNow()
+	}
+	got, err := KeyUnwrapCryptoPro(kek, ukm, expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(got, cek) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestKeyUnwrapCryptoProTampered(t *testing.T) {
+	kek := make([]byte, KeySize)
+	cek := make([]byte, KeySize)
+	ukm := make([]byte, BlockSize)
+	rand.Read(kek)
+	rand.Read(cek)
+	rand.Read(ukm)
+	wrapped, err := KeyWrapCryptoPro(kek, ukm, cek)
+	if err != nil {
+		t.FailNow()
+	}
+	for i := 0; i < len(wrapped); i++ {
+		wrapped[i] ^= 0x80
+		if _, err = KeyUnwrapCryptoPro(kek, ukm, wrapped); err == nil {
+			t.Fatal("tampered byte", i)
+		}
+		wrapped[i] ^= 0x80
+	}
+	ukm[0] ^= 0x01
+	if _, err = KeyUnwrapCryptoPro(kek, ukm, wrapped); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestKeyWrapSymmetric(t *testing.T) {
+	f := func(kek, cek [KeySize]byte, ukm [BlockSize]byte) bool {
+		wrapped, err := KeyWrap(kek[:], ukm[:], cek[:])
+		if err != nil || len(wrapped) != WrappedKeySize {
+			return false
+		}
+		got, err := KeyUnwrap(kek[:], ukm[:], wrapped)
+		return err == nil && bytes.Compare(got, cek[:]) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestKeyWrapDiversified(t *testing.T) {
+	kek := make([]byte, KeySize)
+	cek := make([]byte, KeySize)
+	ukm := make([]byte, BlockSize)
+	rand.Read(kek)
+	rand.Read(cek)
+	rand.Read(ukm)
+	plain, err := KeyWrap(kek, ukm, cek)
+	if err != nil {
+		t.FailNow()
+	}
+	cpro, err := KeyWrapCryptoPro(kek, ukm, cek)
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(plain, cpro) == 0 {
+		t.FailNow()
+	}
+	diversified, err := KEKDiversify(kek, ukm, SboxDefault)
+	if err != nil {
+		t.FailNow()
+	}
+	ours, err := KeyWrap(diversified, ukm, cek)
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(ours, cpro) != 0 {
+		t.FailNow()
+	}
+	if _, err = KeyUnwrap(kek, ukm, cpro); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestKEKDiversify(t *testing.T) {
+	kek := make([]byte, KeySize)
+	for i := 0; i < KeySize; i++ {
+		kek[i] = byte(i)
+	}
+	ukm := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	a, err := KEKDiversify(kek, ukm, SboxDefault)
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(a, kek) == 0 {
+		t.FailNow()
+	}
+	b, _ := KEKDiversify(kek, ukm, &SboxIdGost2814789CryptoProBParamSet)
+	if bytes.Compare(a, b) == 0 {
+		t.FailNow()
+	}
+	ukm[7] ^= 0x80
+	c, _ := KEKDiversify(kek, ukm, SboxDefault)
+	if bytes.Compare(a, c) == 0 {
+		t.FailNow()
+	}
+	if _, err = KEKDiversify(kek[1:], ukm, SboxDefault); err == nil {
+		t.FailNow()
+	}
+	if _, err = KEKDiversify(kek, ukm[1:], SboxDefault); err == nil {
+		t.FailNow()
+	}
+	if _, err = KEKDiversify(kek, ukm, nil); err == nil {
+		t.FailNow()
+	}
+}
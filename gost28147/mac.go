@@ -36,6 +36,19 @@ type MAC struct {
 	n2   nv
 }
 
+// Create MAC keyed with key under sbox, using all-zero initialization
+// vector. It is the same as NewCipher(key, sbox).NewMAC(size, zeroIV).
+// Incomplete final block is zero padded in Sum.
+func NewMAC(key []byte, size int, sbox *Sbox) (*MAC, error) {
+	if len(key) != KeySize {
+		return nil, errors.New("gogost/gost28147: len(key) != 32")
+	}
+	if sbox == nil {
+		return nil, errors.New("gogost/gost28147: nil sbox")
+	}
+	return NewCipher(key, sbox).NewMAC(size, make([]byte, BlockSize))
+}
+
 // Create MAC with given tag size and initial initialization vector.
 // Size is in bytes and must be between 1 and 8. To be RFC conformant,
 // iv must be the first block of the authenticated data, second and
@@ -87,16 +100,13 @@ func (m *MAC) Sum(b []byte) []byte {
 	if len(m.buf) == 0 {
 		return append(b, m.prev[0:m.size]...)
 	}
-	buf := m.buf
-	var i int
-	for i = 0; i < BlockSize-len(m.buf); i++ {
-		buf = append(buf, byte(0))
-	}
-	for i = 0; i < BlockSize; i++ {
+	buf := make([]byte, BlockSize)
+	copy(buf, m.buf)
+	for i := 0; i < BlockSize; i++ {
 		buf[i] ^= m.prev[i]
 	}
-	m.n1, m.n2 = block2nvs(buf)
-	m.n1, m.n2 = m.c.xcrypt(SeqMAC, m.n1, m.n2)
-	nvs2block(m.n2, m.n1, buf)
+	n1, n2 := block2nvs(buf)
+	n1, n2 = m.c.xcrypt(SeqMAC, n1, n2)
+	nvs2block(n2, n1, buf)
 	return append(b, buf[0:m.size]...)
 }
@@ -68,6 +68,28 @@ func TestMACVectors(t *testing.T) {
 	})
 }
 
+func TestMACNew(t *testing.T) {
+	key := []byte("This is message\xFF length\x0032 bytes")
+	m, err := NewMAC(key, 4, SboxDefault)
+	if err != nil {
+		t.FailNow()
+	}
+	var _ hash.Hash = m
+	m.Write(bytes.Repeat([]byte("U"), 128))
+	if bytes.Compare(m.Sum(nil), []byte{0x1a, 0x06, 0xd1, 0xba}) != 0 {
+		t.FailNow()
+	}
+	if _, err = NewMAC(key[:31], 4, SboxDefault); err == nil {
+		t.FailNow()
+	}
+	if _, err = NewMAC(key, 4, nil); err == nil {
+		t.FailNow()
+	}
+	if _, err = NewMAC(key, 9, SboxDefault); err == nil {
+		t.FailNow()
+	}
+}
+
 func TestMACRandom(t *testing.T) {
 	var key [KeySize]byte
 	rand.Read(key[:])
@@ -87,11 +109,11 @@ func TestMACRandom(t *testing.T) {
 		for _, b := range data {
 			m.Write([]byte{b})
 		}
-		m.Sum(tag1)
+		tag1 = m.Sum(tag1)
 
 		m.Reset()
 		m.Write(data)
-		m.Sum(tag2)
+		tag2 = m.Sum(tag2)
 
 		return bytes.Compare(tag1, tag2) == 0
 	}
@@ -100,6 +122,33 @@ func TestMACRandom(t *testing.T) {
 	}
 }
 
+func TestMACSumKeepsState(t *testing.T) {
+	var key [KeySize]byte
+	rand.Read(key[:])
+	c := NewCipher(key[:], SboxDefault)
+	f := func(iv [BlockSize]byte, data1, data2 []byte) bool {
+		m, err := c.NewMAC(4, iv[:])
+		if err != nil {
+			return false
+		}
+		m.Write(data1)
+		m.Write(data2)
+		tag := m.Sum(nil)
+
+		m.Reset()
+		m.Write(data1)
+		tag1 := m.Sum(nil)
+		if bytes.Compare(tag1, m.Sum(nil)) != 0 {
+			return false
+		}
+		m.Write(data2)
+		return bytes.Compare(m.Sum(nil), tag) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestMACInterface(t *testing.T) {
 	var key [KeySize]byte
 	var iv [8]byte
@@ -0,0 +1,76 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"crypto/cipher"
+)
+
+// OFB mode: keystream blocks are successive encryptions of the
+// feedback register, initially equal to IV. Encryption and decryption
+// are the same operation. XORKeyStream can be called with any lengths,
+// unused keystream bytes are kept for the next call.
+type OFB struct {
+	c         *Cipher
+	iv        []byte
+	meshing   bool
+	processed int
+	used      int
+}
+
+func (c *Cipher) NewOFB(iv []byte) *OFB {
+	if len(iv) != BlockSize {
+		panic("iv length is not equal to blocksize")
+	}
+	ofb := OFB{c: c, iv: make([]byte, BlockSize), used: BlockSize}
+	copy(ofb.iv, iv)
+	return &ofb
+}
+
+// OFB with CryptoPro key meshing (RFC 4357) made after each
+// MeshingInterval bytes. As with CFB, the key is meshed and the
+// feedback register is encrypted with the new key before producing the
+// next keystream block.
+func (c *Cipher) NewOFBMeshing(iv []byte) *OFB {
+	ofb := c.NewOFB(iv)
+	ofb.meshing = true
+	return ofb
+}
+
+// Create OFB keyed with key under sbox, without key meshing. It panics
+// if key, sbox or iv are invalid, like NewCipher and Cipher.NewOFB do.
+func NewOFB(key, iv []byte, sbox *Sbox) cipher.Stream {
+	return NewCipher(key, sbox).NewOFB(iv)
+}
+
+func (c *OFB) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("dst is too short")
+	}
+	for i := 0; i < len(src); i++ {
+		if c.used == BlockSize {
+			if c.meshing && c.processed == MeshingInterval {
+				c.c = c.c.mesh(c.iv)
+				c.processed = 0
+			}
+			c.c.Encrypt(c.iv, c.iv)
+			c.processed += BlockSize
+			c.used = 0
+		}
+		dst[i] = src[i] ^ c.iv[c.used]
+		c.used++
+	}
+}
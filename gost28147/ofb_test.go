@@ -0,0 +1,143 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"testing"
+	"testing/quick"
+)
+
+// First keystream block is the encryption of IV, so GOST R 34.12-2015
+// A.2 vector (with reversed words, see WordOrderLE) gives it. Following
+// ones are the encryptions of the previous.
+func TestOFBVector(t *testing.T) {
+	key := []byte{
+		0xcc, 0xdd, 0xee, 0xff, 0x88, 0x99, 0xaa, 0xbb,
+		0x44, 0x55, 0x66, 0x77, 0x00, 0x11, 0x22, 0x33,
+		0xf3, 0xf2, 0xf1, 0xf0, 0xf7, 0xf6, 0xf5, 0xf4,
+		0xfb, 0xfa, 0xf9, 0xf8, 0xff, 0xfe, 0xfd, 0xfc,
+	}
+	iv := []byte{0x10, 0x32, 0x54, 0x76, 0x98, 0xba, 0xdc, 0xfe}
+	pt := []byte{
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88,
+	}
+	keystream := make([]byte, 2*BlockSize)
+	copy(keystream, []byte{0x3d, 0xca, 0xd8, 0xc2, 0xe5, 0x01, 0xe9, 0x4e})
+	c := NewCipher(key, &SboxIdtc26gost28147paramZ)
+	c.Encrypt(keystream[BlockSize:], keystream[:BlockSize])
+	ct := make([]byte, len(pt))
+	for i := range pt {
+		ct[i] = pt[i] ^ keystream[i]
+	}
+	tmp := make([]byte, len(pt))
+	NewOFB(key, iv, &SboxIdtc26gost28147paramZ).XORKeyStream(tmp, pt)
+	if bytes.Compare(tmp, ct) != 0 {
+		t.FailNow()
+	}
+	c.NewOFB(iv).XORKeyStream(tmp, tmp)
+	if bytes.Compare(tmp, pt) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestOFBSymmetric(t *testing.T) {
+	f := func(key [KeySize]byte, iv [BlockSize]byte, pt []byte) bool {
+		c := NewCipher(key[:], SboxDefault)
+		ct := make([]byte, len(pt))
+		c.NewOFB(iv[:]).XORKeyStream(ct, pt)
+		c.NewOFB(iv[:]).XORKeyStream(ct, ct)
+		return bytes.Compare(ct, pt) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOFBInterface(t *testing.T) {
+	c := NewCipher(make([]byte, KeySize), SboxDefault)
+	var _ cipher.Stream = c.NewOFB(make([]byte, BlockSize))
+	defer func() {
+		if recover() == nil {
+			t.FailNow()
+		}
+	}()
+	c.NewOFB(make([]byte, BlockSize-1))
+}
+
+func TestOFBBlockBoundaryCalls(t *testing.T) {
+	c := NewCipher(make([]byte, KeySize), SboxDefault)
+	iv := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	pt := make([]byte, 3*MeshingInterval+5)
+	for i := range pt {
+		pt[i] = byte(i)
+	}
+	for _, meshing := range []bool{false, true} {
+		newOFB := c.NewOFB
+		if meshing {
+			newOFB = c.NewOFBMeshing
+		}
+		ct := make([]byte, len(pt))
+		newOFB(iv).XORKeyStream(ct, pt)
+		ofb := newOFB(iv)
+		tmp := make([]byte, len(pt))
+		for i, step := 0, 1; i < len(pt); i, step = i+step, step%13+1 {
+			end := i + step
+			if end > len(pt) {
+				end = len(pt)
+			}
+			ofb.XORKeyStream(tmp[i:end], pt[i:end])
+		}
+		if bytes.Compare(tmp, ct) != 0 {
+			t.Fatal(meshing)
+		}
+	}
+}
+
+func TestOFBMeshing(t *testing.T) {
+	key := make([]byte, KeySize)
+	for i := 0; i < KeySize; i++ {
+		key[i] = byte(i)
+	}
+	iv := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	c := NewCipher(key, &SboxIdGost2814789CryptoProAParamSet)
+	pt := make([]byte, 2*MeshingInterval)
+	ksPlain := make([]byte, len(pt))
+	c.NewOFB(iv).XORKeyStream(ksPlain, pt)
+	ks := make([]byte, len(pt))
+	c.NewOFBMeshing(iv).XORKeyStream(ks, pt)
+	if bytes.Compare(ks[:MeshingInterval], ksPlain[:MeshingInterval]) != 0 {
+		t.FailNow()
+	}
+	if bytes.Compare(ks[MeshingInterval:], ksPlain[MeshingInterval:]) == 0 {
+		t.FailNow()
+	}
+
+	// Manually meshed key and register must continue the stream
+	meshedKey := make([]byte, KeySize)
+	c.NewECBDecrypter().CryptBlocks(meshedKey, meshingC[:])
+	register := make([]byte, BlockSize)
+	copy(register, ks[MeshingInterval-BlockSize:MeshingInterval])
+	meshed := NewCipher(meshedKey, &SboxIdGost2814789CryptoProAParamSet)
+	meshed.Encrypt(register, register)
+	tmp := make([]byte, MeshingInterval)
+	meshed.NewOFB(register).XORKeyStream(tmp, pt[MeshingInterval:])
+	if bytes.Compare(tmp, ks[MeshingInterval:]) != 0 {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,97 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"crypto/cipher"
+)
+
+// Byte order of 32-bit words of the key and of the block halves.
+// Implementations disagree on it, so the same key and plaintext bytes
+// give different ciphertexts in them.
+type WordOrder int
+
+const (
+	// Little-endian words, low one first: RFC 5830, RFC 4357 and
+	// CryptoPro-compatible software. Cipher always uses it.
+	WordOrderLE WordOrder = iota
+
+	// Big-endian words, high one first: the whole key and block are
+	// big-endian numbers, as GOST R 34.12-2015 defines for Magma.
+	WordOrderBE
+
+	// Convention of CryptoPro and RFCs 4357/4490 based protocols.
+	WordOrderCryptoPro = WordOrderLE
+)
+
+// 28147-89 with big-endian words: both key and block bytes are
+// reversed, like gost341264 does.
+type cipherBE struct {
+	c *Cipher
+}
+
+// Create the cipher with the given key, S-box and word order. For
+// WordOrderLE it is the same as NewCipher. Only ECB-like use through
+// cipher.Block interface (for example with crypto/cipher modes) is
+// available for WordOrderBE, as CNT, CFB and MAC of this package are
+// defined for little-endian one. It panics if key has invalid size,
+// S-box is nil or order is unknown.
+func NewCipherOrder(key []byte, sbox *Sbox, order WordOrder) cipher.Block {
+	switch order {
+	case WordOrderLE:
+		return NewCipher(key, sbox)
+	case WordOrderBE:
+		if len(key) != KeySize {
+			panic("invalid key size")
+		}
+		k := make([]byte, KeySize)
+		for i := 0; i < KeySize; i += 4 {
+			k[i+0], k[i+1], k[i+2], k[i+3] = key[i+3], key[i+2], key[i+1], key[i+0]
+		}
+		c := NewCipher(k, sbox)
+		for i := range k {
+			k[i] = 0
+		}
+		return &cipherBE{c}
+	}
+	panic("unknown word order")
+}
+
+func (c *cipherBE) BlockSize() int {
+	return BlockSize
+}
+
+func reverseBlock(dst, src []byte) {
+	_ = src[BlockSize-1]
+	_ = dst[BlockSize-1]
+	var b [BlockSize]byte
+	for i := 0; i < BlockSize; i++ {
+		b[i] = src[BlockSize-1-i]
+	}
+	copy(dst, b[:])
+}
+
+func (c *cipherBE) Encrypt(dst, src []byte) {
+	reverseBlock(dst, src)
+	c.c.Encrypt(dst, dst)
+	reverseBlock(dst, dst)
+}
+
+func (c *cipherBE) Decrypt(dst, src []byte) {
+	reverseBlock(dst, src)
+	c.c.Decrypt(dst, dst)
+	reverseBlock(dst, dst)
+}
@@ -0,0 +1,88 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+)
+
+func TestWordOrderVectors(t *testing.T) {
+	for _, v := range []struct {
+		order WordOrder
+		key   string
+		pt    string
+		ct    string
+	}{
+		// GOST R 34.12-2015 A.2 vector with bytes of each word reversed
+		{
+			WordOrderLE,
+			"ccddeeff8899aabb4455667700112233f3f2f1f0f7f6f5f4fbfaf9f8fffefdfc",
+			"1032547698badcfe",
+			"3dcad8c2e501e94e",
+		},
+		// GOST R 34.12-2015 A.2 vector as is
+		{
+			WordOrderBE,
+			"ffeeddccbbaa99887766554433221100f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff",
+			"fedcba9876543210",
+			"4ee901e5c2d8ca3d",
+		},
+	} {
+		key, _ := hex.DecodeString(v.key)
+		pt, _ := hex.DecodeString(v.pt)
+		ct, _ := hex.DecodeString(v.ct)
+		c := NewCipherOrder(key, &SboxIdtc26gost28147paramZ, v.order)
+		dst := make([]byte, BlockSize)
+		c.Encrypt(dst, pt)
+		if bytes.Compare(dst, ct) != 0 {
+			t.Fatal(v.order, hex.EncodeToString(dst))
+		}
+		c.Decrypt(dst, dst)
+		if bytes.Compare(dst, pt) != 0 {
+			t.Fatal(v.order)
+		}
+	}
+}
+
+func TestWordOrderCryptoPro(t *testing.T) {
+	key := make([]byte, KeySize)
+	rand.Read(key)
+	pt := make([]byte, BlockSize)
+	rand.Read(pt)
+	ref := make([]byte, BlockSize)
+	NewCipher(key, SboxDefault).Encrypt(ref, pt)
+	dst := make([]byte, BlockSize)
+	NewCipherOrder(key, SboxDefault, WordOrderCryptoPro).Encrypt(dst, pt)
+	if bytes.Compare(dst, ref) != 0 {
+		t.FailNow()
+	}
+	NewCipherOrder(key, SboxDefault, WordOrderBE).Encrypt(dst, pt)
+	if bytes.Compare(dst, ref) == 0 {
+		t.FailNow()
+	}
+}
+
+func TestWordOrderUnknown(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.FailNow()
+		}
+	}()
+	NewCipherOrder(make([]byte, KeySize), SboxDefault, WordOrder(2))
+}
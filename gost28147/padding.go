@@ -0,0 +1,60 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+// Pad data with zeros up to BlockSize multiple. Data already being
+// multiple is not padded. Padding can not be removed unambiguously.
+func PadZero(data []byte) []byte {
+	if len(data)%BlockSize == 0 {
+		return data
+	}
+	return append(data, make([]byte, BlockSize-len(data)%BlockSize)...)
+}
+
+// Pad data with PKCS#7 (RFC 5652 6.3) up to BlockSize multiple. Full
+// block of padding is added to data already being multiple.
+func PadPKCS7(data []byte) []byte {
+	padSize := BlockSize - len(data)%BlockSize
+	for i := 0; i < padSize; i++ {
+		data = append(data, byte(padSize))
+	}
+	return data
+}
+
+// Remove PKCS#7 padding. Padding bytes are checked without branches
+// on their values.
+func UnpadPKCS7(data []byte) ([]byte, error) {
+	if len(data) == 0 || len(data)%BlockSize != 0 {
+		return nil, errors.New("gogost/gost28147: invalid padded data length")
+	}
+	padSize := data[len(data)-1]
+	good := subtle.ConstantTimeLessOrEq(1, int(padSize)) &
+		subtle.ConstantTimeLessOrEq(int(padSize), BlockSize)
+	for i := 1; i <= BlockSize; i++ {
+		inPad := subtle.ConstantTimeLessOrEq(i, int(padSize))
+		eq := subtle.ConstantTimeByteEq(data[len(data)-i], padSize)
+		good &= subtle.ConstantTimeSelect(inPad, eq, 1)
+	}
+	if good != 1 {
+		return nil, errors.New("gogost/gost28147: invalid padding")
+	}
+	return data[:len(data)-int(padSize)], nil
+}
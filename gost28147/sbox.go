@@ -15,6 +15,8 @@
 
 package gost28147
 
+import "sync"
+
 // Sbox is a representation of eight substitution boxes.
 type Sbox [8][16]uint8
 
@@ -113,6 +115,43 @@ var (
 	SboxDefault = &SboxIdGost2814789CryptoProAParamSet
 )
 
+// Known S-boxes with their ASN.1 names. OIDs are:
+//
+//	id-Gost28147-89-TestParamSet          1.2.643.2.2.31.0
+//	id-Gost28147-89-CryptoPro-A-ParamSet  1.2.643.2.2.31.1
+//	id-Gost28147-89-CryptoPro-B-ParamSet  1.2.643.2.2.31.2
+//	id-Gost28147-89-CryptoPro-C-ParamSet  1.2.643.2.2.31.3
+//	id-Gost28147-89-CryptoPro-D-ParamSet  1.2.643.2.2.31.4
+//	id-tc26-gost-28147-param-Z            1.2.643.7.1.2.5.1.1
+//	id-GostR3411-94-TestParamSet          1.2.643.2.2.30.0
+//	id-GostR3411-94-CryptoProParamSet     1.2.643.2.2.30.1
+//
+// EAC's S-box has no OID.
+var sboxes = []struct {
+	name string
+	sbox *Sbox
+}{
+	{"id-Gost28147-89-TestParamSet", &SboxIdGost2814789TestParamSet},
+	{"id-Gost28147-89-CryptoPro-A-ParamSet", &SboxIdGost2814789CryptoProAParamSet},
+	{"id-Gost28147-89-CryptoPro-B-ParamSet", &SboxIdGost2814789CryptoProBParamSet},
+	{"id-Gost28147-89-CryptoPro-C-ParamSet", &SboxIdGost2814789CryptoProCParamSet},
+	{"id-Gost28147-89-CryptoPro-D-ParamSet", &SboxIdGost2814789CryptoProDParamSet},
+	{"id-tc26-gost-28147-param-Z", &SboxIdtc26gost28147paramZ},
+	{"id-GostR3411-94-TestParamSet", &SboxIdGostR341194TestParamSet},
+	{"id-GostR3411-94-CryptoProParamSet", &SboxIdGostR341194CryptoProParamSet},
+	{"EACParamSet", &SboxEACParamSet},
+}
+
+// Get known S-box by its name, like "id-tc26-gost-28147-param-Z".
+func SboxByName(name string) (*Sbox, bool) {
+	for _, known := range sboxes {
+		if known.name == name {
+			return known.sbox, true
+		}
+	}
+	return nil, false
+}
+
 // Sbox substitution itself.
 func (s *Sbox) k(n nv) nv {
 	return nv(s[0][(n>>0)&0x0F])<<0 +
@@ -124,3 +163,52 @@ func (s *Sbox) k(n nv) nv {
 		nv(s[6][(n>>24)&0x0F])<<24 +
 		nv(s[7][(n>>28)&0x0F])<<28
 }
+
+// Substitution followed by cyclic 11-bit shift, as four byte-indexed
+// lookup tables: each of them combines two adjacent S-boxes. Shift is
+// linear, so XOR of all four lookups equals k(n).shift11().
+type sboxTables [4][256]nv
+
+func (s *Sbox) newTables() *sboxTables {
+	var t sboxTables
+	for j := 0; j < 4; j++ {
+		for b := 0; b < 256; b++ {
+			t[j][b] = (nv(s[2*j][b&0x0F]) | nv(s[2*j+1][b>>4])<<4) << (8 * uint(j))
+			t[j][b] = t[j][b].shift11()
+		}
+	}
+	return &t
+}
+
+type sboxTablesEntry struct {
+	sbox   Sbox
+	tables *sboxTables
+}
+
+// Tables of predefined S-boxes are computed once, as ciphers are often
+// created with them, for example during CFB key meshing. S-box is
+// found by its value, so the modified or caller's own S-box is not
+// confused with them: its tables are computed on each call and not
+// kept.
+var (
+	sboxTablesKnownOnce sync.Once
+	sboxTablesKnown     []sboxTablesEntry
+)
+
+func (s *Sbox) tables() *sboxTables {
+	sboxTablesKnownOnce.Do(func() {
+		sboxTablesKnown = make([]sboxTablesEntry, 0, len(sboxes))
+		for _, known := range sboxes {
+			sboxTablesKnown = append(sboxTablesKnown, sboxTablesEntry{
+				sbox:   *known.sbox,
+				tables: known.sbox.newTables(),
+			})
+		}
+	})
+	for i := range sboxTablesKnown {
+		if sboxTablesKnown[i].sbox == *s {
+			return sboxTablesKnown[i].tables
+		}
+	}
+	return s.newTables()
+}
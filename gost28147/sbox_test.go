@@ -0,0 +1,95 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost28147
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSboxByName(t *testing.T) {
+	for i, known := range sboxes {
+		sbox, ok := SboxByName(known.name)
+		if !ok || sbox != known.sbox {
+			t.Fatal(known.name)
+		}
+		for _, other := range sboxes[i+1:] {
+			if *other.sbox == *known.sbox {
+				t.Fatal(known.name, "equals to", other.name)
+			}
+		}
+	}
+	if _, ok := SboxByName("id-unknown-ParamSet"); ok {
+		t.FailNow()
+	}
+}
+
+func TestSboxByNameParamZ(t *testing.T) {
+	sbox, ok := SboxByName("id-tc26-gost-28147-param-Z")
+	if !ok {
+		t.FailNow()
+	}
+	c := NewCipher([]byte{
+		0xcc, 0xdd, 0xee, 0xff, 0x88, 0x99, 0xaa, 0xbb,
+		0x44, 0x55, 0x66, 0x77, 0x00, 0x11, 0x22, 0x33,
+		0xf3, 0xf2, 0xf1, 0xf0, 0xf7, 0xf6, 0xf5, 0xf4,
+		0xfb, 0xfa, 0xf9, 0xf8, 0xff, 0xfe, 0xfd, 0xfc,
+	}, sbox)
+	dst := make([]byte, BlockSize)
+	c.Encrypt(dst, []byte{0x10, 0x32, 0x54, 0x76, 0x98, 0xba, 0xdc, 0xfe})
+	if bytes.Compare(dst, []byte{0x3d, 0xca, 0xd8, 0xc2, 0xe5, 0x01, 0xe9, 0x4e}) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestSboxTables(t *testing.T) {
+	for _, known := range sboxes {
+		tables := known.sbox.tables()
+		for _, n := range []nv{0, 1, 0x12345678, 0x89ABCDEF, 0xFFFFFFFF} {
+			got := tables[0][n&0xFF] ^ tables[1][(n>>8)&0xFF] ^
+				tables[2][(n>>16)&0xFF] ^ tables[3][n>>24]
+			if got != known.sbox.k(n).shift11() {
+				t.Fatal(known.name)
+			}
+		}
+	}
+}
+
+func TestSboxTablesModified(t *testing.T) {
+	sbox := SboxDefault
+	local := *sbox
+	key := make([]byte, KeySize)
+	src := make([]byte, BlockSize)
+	before := make([]byte, BlockSize)
+	NewCipher(key, &local).Encrypt(before, src)
+	local[0][0], local[0][1] = local[0][1], local[0][0]
+	after := make([]byte, BlockSize)
+	NewCipher(key, &local).Encrypt(after, src)
+	if bytes.Compare(before, after) == 0 {
+		t.Fatal("S-box modification is not noticed")
+	}
+}
+
+func TestSboxTablesNotKept(t *testing.T) {
+	local := *SboxDefault
+	if local.tables() != SboxDefault.tables() {
+		t.Fatal("predefined S-box copy tables are not shared")
+	}
+	local[0][0], local[0][1] = local[0][1], local[0][0]
+	if local.tables() == local.tables() {
+		t.Fatal("custom S-box tables are kept")
+	}
+}
@@ -18,6 +18,7 @@ package gost3410
 import (
 	"bytes"
 	"crypto/rand"
+	"math/big"
 	"testing"
 	"testing/quick"
 )
@@ -85,12 +86,41 @@ func TestRFCVectors(t *testing.T) {
 	if err != nil || !valid {
 		t.FailNow()
 	}
+	k, _ := big.NewInt(0).SetString("77105C9B20BCD3122823C8CF6FCC7B956DE33814E95B7FE64FED924594DCEAB3", 16)
+	ourSign, err = prv.SignDigestWithK(digest, k)
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(ourSign, signature) != 0 {
+		t.FailNow()
+	}
+	ourSign, err = prv.SignDigest(digest, bytes.NewReader(k.Bytes()))
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(ourSign, signature) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestSignDigestWithKRange(t *testing.T) {
+	c := CurveIdGostR34102001TestParamSet()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	digest := make([]byte, 32)
+	for _, k := range []*big.Int{big.NewInt(0), big.NewInt(-1), c.Q} {
+		if _, err = prv.SignDigestWithK(digest, k); err == nil {
+			t.Fatal(k)
+		}
+	}
 }
 
 func TestRandom2001(t *testing.T) {
 	c := CurveIdGostR34102001TestParamSet()
 	f := func(data [31]byte, digest [32]byte) bool {
-		prv, err := NewPrivateKey(c, append([]byte{0xde}, data[:]...))
+		prv, err := NewPrivateKeyReduce(c, append([]byte{0xde}, data[:]...))
 		if err != nil {
 			return false
 		}
@@ -181,3 +211,41 @@ func TestPrvEqualsTo1(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestVerifyMalformed(t *testing.T) {
+	c := CurveIdGostR34102001TestParamSet()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	digest := make([]byte, 32)
+	rand.Read(digest)
+	sign, err := prv.SignDigest(digest, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	if _, err = pub.VerifyDigest(digest, sign[1:]); err == nil {
+		t.FailNow()
+	}
+	if _, err = pub.VerifyDigest(digest, append(sign, 0x00)); err == nil {
+		t.FailNow()
+	}
+	for i := 0; i < len(sign); i++ {
+		tampered := make([]byte, len(sign))
+		copy(tampered, sign)
+		tampered[i] ^= 0x01
+		valid, err := pub.VerifyDigest(digest, tampered)
+		if err != nil || valid {
+			t.FailNow()
+		}
+	}
+	digest[0] ^= 0x01
+	valid, err := pub.VerifyDigest(digest, sign)
+	if err != nil || valid {
+		t.FailNow()
+	}
+}
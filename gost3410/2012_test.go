@@ -193,6 +193,14 @@ func TestStdVector2(t *testing.T) {
 	if bytes.Compare(sign, append(s, r...)) != 0 {
 		t.FailNow()
 	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	valid, err := pub.VerifyDigest(dgst, sign)
+	if err != nil || !valid {
+		t.FailNow()
+	}
 }
 
 func TestGCL3Vectors(t *testing.T) {
@@ -359,7 +367,7 @@ func TestGCL3Vectors(t *testing.T) {
 func TestRandom2012(t *testing.T) {
 	c := CurveIdtc26gost341012512paramSetA()
 	f := func(prvRaw [64 - 1]byte, digest [64]byte) bool {
-		prv, err := NewPrivateKey(c, append([]byte{0xde}, prvRaw[:]...))
+		prv, err := NewPrivateKeyReduce(c, append([]byte{0xde}, prvRaw[:]...))
 		if err != nil {
 			return false
 		}
@@ -611,3 +619,73 @@ func BenchmarkVerify2012(b *testing.B) {
 		pub.VerifyDigest(digest, sign)
 	}
 }
+
+func BenchmarkSign2012Parallel(b *testing.B) {
+	c := CurveIdtc26gost341012512paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		b.FailNow()
+	}
+	digest := make([]byte, 64)
+	rand.Read(digest)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			prv.SignDigest(digest, rand.Reader)
+		}
+	})
+}
+
+func edwardsContains(c *Curve, u, v *big.Int) bool {
+	u2 := big.NewInt(0).Mul(u, u)
+	v2 := big.NewInt(0).Mul(v, v)
+	left := big.NewInt(0).Mul(c.E, u2)
+	left.Add(left, v2)
+	left.Mod(left, c.P)
+	right := big.NewInt(0).Mul(c.D, u2)
+	right.Mul(right, v2)
+	right.Add(right, bigInt1)
+	right.Mod(right, c.P)
+	return left.Cmp(right) == 0
+}
+
+// Keys exchanged in twisted Edwards coordinates interoperate with
+// Weierstrass signing and verification.
+func TestEdwardsInterop(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdtc26gost34102012256paramSetA(),
+		CurveIdtc26gost34102012512paramSetC(),
+	} {
+		if !c.IsEdwards() {
+			t.FailNow()
+		}
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, _ := prv.PublicKey()
+		u, v := XY2UV(c, pub.X, pub.Y)
+		if !edwardsContains(c, u, v) {
+			t.Fatal(c.Name)
+		}
+		x, y := UV2XY(c, u, v)
+		pubConverted := &PublicKey{c, x, y}
+		if !pubConverted.Equal(pub) {
+			t.Fatal(c.Name)
+		}
+		digest := make([]byte, c.PointSize())
+		rand.Read(digest)
+		sig, err := prv.SignDigest(digest, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid, err := pubConverted.VerifyDigest(digest, sig)
+		if err != nil || !valid {
+			t.Fatal(c.Name)
+		}
+	}
+	if CurveIdtc26gost341012512paramSetA().IsEdwards() {
+		t.FailNow()
+	}
+}
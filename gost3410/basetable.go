@@ -0,0 +1,118 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"math/big"
+	"sync"
+)
+
+// Window width in bits of the base point table.
+const baseWindow = 4
+
+// Precomputed multiples of the base point: table[i][j] = j*2^(w*i)*G,
+// table[i][0] is the point at infinity.
+type baseTable [][1 << baseWindow]pPoint
+
+// Tables are shared between all Curve instances with the same
+// parameters, because predefined curves constructors create new
+// instances every time.
+// Each table is computed once, without holding the cache's lock.
+type baseTableOnce struct {
+	once  sync.Once
+	table baseTable
+}
+
+// Tables of 512-bit curves take 384 KiB each. Capacity is
+// enough for all predefined curves, other ones may just be evicted and
+// recomputed later. Signer keeps its table itself.
+var baseTables = newLRUCache(16)
+
+// Tables map key, remembered together with the values it was made of.
+// Like with the field, parameters replaced after NewCurve are detected
+// by pointers comparison, so the key is not rebuilt on each call.
+type baseKey struct {
+	p, q, a, x, y *big.Int
+	key           string
+}
+
+func newBaseKey(c *Curve) *baseKey {
+	return &baseKey{
+		p: c.P, q: c.Q, a: c.A, x: c.X, y: c.Y,
+		key: c.P.Text(16) + ":" + c.Q.Text(16) + ":" + c.A.Text(16) + ":" +
+			c.X.Text(16) + ":" + c.Y.Text(16),
+	}
+}
+
+// Get base point table, computing it at the first call. It takes about
+// the time of several Exp calls.
+func (c *Curve) baseTable() baseTable {
+	bk := c.bk
+	if bk == nil || bk.p != c.P || bk.q != c.Q || bk.a != c.A ||
+		bk.x != c.X || bk.y != c.Y {
+		bk = newBaseKey(c)
+	}
+	bt := baseTables.getOrAdd(bk.key, func() interface{} {
+		return new(baseTableOnce)
+	}).(*baseTableOnce)
+	bt.once.Do(func() { bt.table = c.computeBaseTable() })
+	return bt.table
+}
+
+func (c *Curve) computeBaseTable() baseTable {
+	f := c.fp
+	table := make(baseTable, (c.Q.BitLen()+baseWindow-1)/baseWindow)
+	g := pPoint{x: f.fromBig(c.X), y: f.fromBig(c.Y), z: f.one}
+	for i := range table {
+		table[i][0] = pPoint{y: f.one}
+		table[i][1] = g
+		for j := 2; j < len(table[i]); j++ {
+			f.addComplete(&table[i][j], &table[i][j-1], &g)
+		}
+		f.addComplete(&g, &table[i][len(table[i])-1], &g)
+	}
+	return table
+}
+
+// Multiply base point with fixed window method over precomputed table:
+// only additions are made, one per window. degree must not be longer
+// than Q. It is constant time: every window reads all table entries,
+// selecting the needed one with a mask, and the complete addition
+// formulas are the same for zero windows too.
+func (c *Curve) expBase(degree *big.Int) (x, y *big.Int, ok bool) {
+	return c.expBaseTable(c.baseTable(), degree)
+}
+
+// expBase with already taken table.
+func (c *Curve) expBaseTable(table baseTable, degree *big.Int) (x, y *big.Int, ok bool) {
+	f := c.fp
+	k := feFromWords(degree)
+	r := pPoint{y: f.one}
+	var e pPoint
+	for i := range table {
+		bit := i * baseWindow
+		d := (k[bit/64] >> uint(bit%64)) & (1<<baseWindow - 1)
+		for j := range table[i] {
+			// mask is all ones if j == d, zero otherwise
+			m := uint64(j) ^ d
+			f.selectPoint(&e, &table[i][j], ((m|-m)>>63)-1)
+		}
+		f.addComplete(&r, &r, &e)
+	}
+	k.clear()
+	e = pPoint{}
+	return f.affineP(&r)
+}
@@ -0,0 +1,83 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestExpBaseEqualsLadder(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		bits := c.Q.BitLen()
+		degrees := []*big.Int{
+			bigInt1, bigInt2, big.NewInt(15), big.NewInt(16), big.NewInt(17),
+			big.NewInt(0).Sub(c.Q, bigInt1),
+		}
+		for i := 0; i < 4; i++ {
+			degree, err := rand.Int(rand.Reader, c.Q)
+			if err != nil {
+				t.FailNow()
+			}
+			degrees = append(degrees, degree.Add(degree, bigInt1))
+		}
+		for _, degree := range degrees {
+			x, y, ok := c.expBase(degree)
+			xRef, yRef, okRef := c.fp.exp(degree, bits, c.X, c.Y)
+			if !ok || !okRef || !PointEqual(x, y, xRef, yRef) {
+				t.Fatal(c.Name, degree)
+			}
+		}
+		if _, _, ok := c.expBase(c.Q); ok {
+			t.Fatal(c.Name, "Q*G is not infinity")
+		}
+	}
+}
+
+func benchmarkExpBase(b *testing.B, c *Curve, viaTable bool) {
+	degree, err := rand.Int(rand.Reader, c.Q)
+	if err != nil {
+		b.FailNow()
+	}
+	c.baseTable()
+	bits := c.Q.BitLen()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if viaTable {
+			c.expBase(degree)
+		} else {
+			c.fp.exp(degree, bits, c.X, c.Y)
+		}
+	}
+}
+
+func BenchmarkExpBaseTable256(b *testing.B) {
+	benchmarkExpBase(b, CurveIdtc26gost341012256paramSetB(), true)
+}
+
+func BenchmarkExpBaseLadder256(b *testing.B) {
+	benchmarkExpBase(b, CurveIdtc26gost341012256paramSetB(), false)
+}
+
+func BenchmarkExpBaseTable512(b *testing.B) {
+	benchmarkExpBase(b, CurveIdtc26gost341012512paramSetA(), true)
+}
+
+func BenchmarkExpBaseLadder512(b *testing.B) {
+	benchmarkExpBase(b, CurveIdtc26gost341012512paramSetA(), false)
+}
@@ -0,0 +1,156 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"context"
+	"math/big"
+)
+
+// Digest and its signature to be verified in a batch.
+type BatchItem struct {
+	Digest []byte
+	Sig    []byte
+}
+
+// Simultaneous z1*G + z2*P multiplication (Straus-Shamir trick) with
+// precomputed {O, G, P, G+P} table.
+func (c *Curve) expStraus(table *[4]point, z1, z2 *big.Int) *point {
+	r := &point{x: big.NewInt(0), y: big.NewInt(0), inf: true}
+	bits := z1.BitLen()
+	if z2.BitLen() > bits {
+		bits = z2.BitLen()
+	}
+	for i := bits - 1; i >= 0; i-- {
+		c.pointDouble(r)
+		c.pointAdd(r, &table[z1.Bit(i)|z2.Bit(i)<<1])
+	}
+	return r
+}
+
+// Window width in bits of the Montgomery form simultaneous
+// multiplication.
+const strausWindow = 4
+
+// Multiples of the point: table[j] = j*P.
+type strausTable [1 << strausWindow]jPoint
+
+func (f *field) newStrausTable(x, y *big.Int) *strausTable {
+	var table strausTable
+	table[1] = jPoint{x: f.fromBig(x), y: f.fromBig(y), z: f.one}
+	for j := 2; j < len(table); j++ {
+		f.addPoints(&table[j], &table[j-1], &table[1])
+	}
+	return &table
+}
+
+// z1*G + z2*P multiplication over Montgomery form field in Jacobian
+// coordinates with fixed window Straus-Shamir trick: doublings are
+// shared and one addition per window is made for each of the points.
+// Tables are made with newStrausTable for G and P.
+func (f *field) expStraus(tableG, tableP *strausTable, z1, z2 *big.Int) (x, y *big.Int, ok bool) {
+	var r jPoint
+	bits := z1.BitLen()
+	if z2.BitLen() > bits {
+		bits = z2.BitLen()
+	}
+	for i := (bits+strausWindow-1)/strausWindow - 1; i >= 0; i-- {
+		for j := 0; j < strausWindow; j++ {
+			f.double(&r, &r)
+		}
+		var d1, d2 uint
+		for j := 0; j < strausWindow; j++ {
+			d1 |= z1.Bit(i*strausWindow+j) << uint(j)
+			d2 |= z2.Bit(i*strausWindow+j) << uint(j)
+		}
+		f.addPoints(&r, &r, &tableG[d1])
+		f.addPoints(&r, &r, &tableP[d2])
+	}
+	return f.affine(&r)
+}
+
+// Verify many signatures made with the same public key. Precomputed
+// points tables are shared between all items and each signature's
+// point is computed with a single simultaneous multiplication, that is
+// done over Montgomery form field on curves made with NewCurve (all
+// predefined ones). Overall result and per-item ones are returned.
+// Items with malformed signatures are checked with VerifyDigest and
+// are just invalid.
+//
+// Signatures are not combined randomly into a single equation, as
+// batch verification of Schnorr-like signatures does. It needs the
+// signature's point R itself, but GOST signature holds only r = R.x
+// mod q: both R and -R, and also the point with x = r + q when it is
+// less than p, match it. Each of n signatures has at least two
+// candidates, so the combined equation would have to be checked for
+// 2^n sign combinations, or each R recovered by the separate
+// verification, that batch is meant to avoid.
+func BatchVerify(pub *PublicKey, items []BatchItem) (bool, []bool, error) {
+	return BatchVerifyContext(context.Background(), pub, items)
+}
+
+// BatchVerify that checks ctx before every item's verification and
+// aborts with ctx.Err() if it is done.
+func BatchVerifyContext(ctx context.Context, pub *PublicKey, items []BatchItem) (bool, []bool, error) {
+	c := pub.C
+	if pub.X == nil || pub.Y == nil {
+		return false, nil, errorf(ErrPointAtInfinity, "gogost/gost3410: public key is the point at infinity")
+	}
+	if !c.contains(pub.X, pub.Y) {
+		return false, nil, ErrPointNotOnCurve
+	}
+	if !c.inSubgroup(pub.X, pub.Y) {
+		return false, nil, ErrPointNotInSubgroup
+	}
+	var f *field
+	var tableG, tableP *strausTable
+	var table [4]point
+	if c.fp != nil && c.fp.pBig == c.P {
+		f = c.fp
+		tableG = f.newStrausTable(c.X, c.Y)
+		tableP = f.newStrausTable(pub.X, pub.Y)
+	} else {
+		table[0] = point{inf: true}
+		table[1] = point{x: c.X, y: c.Y}
+		table[2] = point{x: pub.X, y: pub.Y}
+		table[3] = point{x: big.NewInt(0).Set(c.X), y: big.NewInt(0).Set(c.Y)}
+		c.pointAdd(&table[3], &table[2])
+	}
+	valids := make([]bool, len(items))
+	all := true
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			return false, nil, err
+		}
+		r, z1, z2, err := pub.verifyScalars(item.Digest, item.Sig)
+		if err != nil {
+			valids[i], _ = pub.VerifyDigest(item.Digest, item.Sig)
+		} else if r != nil && f != nil {
+			if x, _, ok := f.expStraus(tableG, tableP, z1, z2); ok {
+				x.Mod(x, c.Q)
+				valids[i] = x.Cmp(r) == 0
+			}
+		} else if r != nil {
+			p := c.expStraus(&table, z1, z2)
+			if !p.inf {
+				p.x.Mod(p.x, c.Q)
+				valids[i] = p.x.Cmp(r) == 0
+			}
+		}
+		all = all && valids[i]
+	}
+	return all, valids, nil
+}
@@ -0,0 +1,173 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+)
+
+func batchItems(prv *PrivateKey, n int) []BatchItem {
+	items := make([]BatchItem, n)
+	for i := 0; i < n; i++ {
+		digest := make([]byte, prv.C.PointSize())
+		rand.Read(digest)
+		sign, err := prv.SignDigest(digest, rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+		items[i] = BatchItem{digest, sign}
+	}
+	return items
+}
+
+func TestBatchVerify(t *testing.T) {
+	cBig := CurveIdtc26gost341012256paramSetB()
+	cBig.fp = nil
+	for _, c := range []*Curve{
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetB(),
+		cBig,
+	} {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		items := batchItems(prv, 16)
+		all, valids, err := BatchVerify(pub, items)
+		if err != nil || !all || len(valids) != len(items) {
+			t.FailNow()
+		}
+		for _, valid := range valids {
+			if !valid {
+				t.FailNow()
+			}
+		}
+		items[3].Sig[5] ^= 0x01
+		items[7].Digest[0] ^= 0x01
+		items[9].Sig = items[9].Sig[1:]
+		all, valids, err = BatchVerify(pub, items)
+		if err != nil || all {
+			t.FailNow()
+		}
+		for i, valid := range valids {
+			expected, _ := pub.VerifyDigest(items[i].Digest, items[i].Sig)
+			if valid != expected {
+				t.FailNow()
+			}
+			if valid != (i != 3 && i != 7 && i != 9) {
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestBatchVerifyEmpty(t *testing.T) {
+	prv, err := GenPrivateKey(CurveIdtc26gost341012256paramSetB(), rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	all, valids, err := BatchVerify(pub, nil)
+	if err != nil || !all || len(valids) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestBatchVerifyContext(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := batchItems(prv, 4)
+	items := make([]BatchItem, 100000)
+	for i := range items {
+		items[i] = signed[i%len(signed)]
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err = BatchVerifyContext(ctx, pub, items); err != context.Canceled {
+		t.Fatal("cancelled context is not respected", err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	started := time.Now()
+	all, valids, err := BatchVerifyContext(ctx, pub, items)
+	if err != context.Canceled {
+		t.Fatal("batch is not cancelled", err)
+	}
+	if all || valids != nil {
+		t.FailNow()
+	}
+	if time.Since(started) > 2*time.Second {
+		t.Fatal("cancellation is not timely")
+	}
+	all, _, err = BatchVerifyContext(context.Background(), pub, signed)
+	if err != nil || !all {
+		t.Fatal("valid batch rejected")
+	}
+}
+
+func BenchmarkBatchVerify1000(b *testing.B) {
+	prv, err := GenPrivateKey(CurveIdtc26gost341012256paramSetB(), rand.Reader)
+	if err != nil {
+		b.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		b.FailNow()
+	}
+	items := batchItems(prv, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(pub, items)
+	}
+}
+
+func BenchmarkSequentialVerify1000(b *testing.B) {
+	prv, err := GenPrivateKey(CurveIdtc26gost341012256paramSetB(), rand.Reader)
+	if err != nil {
+		b.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		b.FailNow()
+	}
+	items := batchItems(prv, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, item := range items {
+			pub.VerifyDigest(item.Digest, item.Sig)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Least recently used values cache of bounded size, safe for concurrent
+// use. Package-wide caches keyed by curve parameters use it, so curves
+// made from untrusted parameters can not grow them infinitely.
+type lruCache struct {
+	capacity int
+	m        sync.Mutex
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Get the value, marking it as recently used.
+func (c *lruCache) get(key string) (interface{}, bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToBack(e)
+	return e.Value.(*lruEntry).value, true
+}
+
+// Get the value, adding the one made by newValue if it is absent. The
+// least recently used value is evicted if capacity is exceeded.
+func (c *lruCache) getOrAdd(key string, newValue func() interface{}) interface{} {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.order.MoveToBack(e)
+		return e.Value.(*lruEntry).value
+	}
+	value := newValue()
+	c.items[key] = c.order.PushBack(&lruEntry{key, value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Front()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+	return value
+}
+
+func (c *lruCache) len() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,64 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := newLRUCache(3)
+	made := 0
+	newValue := func() interface{} {
+		made++
+		return made
+	}
+	for i := 0; i < 3; i++ {
+		c.getOrAdd(strconv.Itoa(i), newValue)
+	}
+	if v, ok := c.get("0"); !ok || v.(int) != 1 {
+		t.FailNow()
+	}
+	c.getOrAdd("3", newValue)
+	if c.len() != 3 {
+		t.FailNow()
+	}
+	if _, ok := c.get("1"); ok {
+		t.Fatal("least recently used value is not evicted")
+	}
+	for _, key := range []string{"0", "2", "3"} {
+		if _, ok := c.get(key); !ok {
+			t.Fatal("value is evicted", key)
+		}
+	}
+	if v := c.getOrAdd("3", newValue); v.(int) != 4 || made != 4 {
+		t.FailNow()
+	}
+}
+
+func TestCachesFitPredefinedCurves(t *testing.T) {
+	tables := make(map[string]bool)
+	for _, curve := range curves {
+		tables[curve().bk.key] = true
+	}
+	if len(tables) > baseTables.capacity {
+		t.Fatal("predefined curves do not fit in base tables cache", len(tables))
+	}
+	if len(curves) > validatedCurves.capacity {
+		t.Fatal("predefined curves do not fit in validated curves cache")
+	}
+}
@@ -51,9 +51,37 @@ type Curve struct {
 	// Cached s/t parameters for Edwards curve points conversion
 	edS *big.Int
 	edT *big.Int
+
+	// Montgomery form field arithmetic for points multiplication
+	fp *field
+
+	// Montgomery form arithmetic modulo Q for signing scalars
+	fq *field
+
+	// Base point table cache key for the parameters set in NewCurve
+	bk *baseKey
+
+	// Was cofactor explicitly specified in NewCurve
+	coSet bool
 }
 
+// Create curve with canonical form equation coefficients a and b over
+// P prime field, subgroup order q and (x, y) base point. e and d
+// twisted Edwards form coefficients are optional, as is the cofactor
+// co, which is 1 by default. Parameters are validated: p and q must be
+// prime, curve must be non-singular, base point must be on the curve
+// and has order q, and cofactor must conform to Hasse's bound (the
+// nearest integer cofactor is checked if co is nil).
+// Validation result is cached for recently used parameters sets.
 func NewCurve(p, q, a, b, x, y, e, d, co *big.Int) (*Curve, error) {
+	for _, v := range []*big.Int{p, q, a, b, x, y} {
+		if v == nil {
+			return nil, errorf(ErrInvalidCurve, "gogost/gost3410: nil curve parameter")
+		}
+	}
+	if co != nil && co.Sign() <= 0 {
+		return nil, errorf(ErrInvalidCurve, "gogost/gost3410: non-positive cofactor")
+	}
 	c := Curve{
 		Name: "unknown",
 		P:    p,
@@ -63,41 +91,213 @@ func NewCurve(p, q, a, b, x, y, e, d, co *big.Int) (*Curve, error) {
 		X:    x,
 		Y:    y,
 	}
-	r1 := big.NewInt(0)
-	r2 := big.NewInt(0)
-	r1.Mul(c.Y, c.Y)
-	r1.Mod(r1, c.P)
-	r2.Mul(c.X, c.X)
-	r2.Add(r2, c.A)
-	r2.Mul(r2, c.X)
-	r2.Add(r2, c.B)
-	r2.Mod(r2, c.P)
-	c.pos(r2)
-	if r1.Cmp(r2) != 0 {
-		return nil, errors.New("gogost/gost3410: invalid curve parameters")
+	if !c.contains(c.X, c.Y) {
+		return nil, errorf(ErrInvalidCurve, "gogost/gost3410: base point is not on the curve")
 	}
 	if e != nil && d != nil {
 		c.E = e
 		c.D = d
+		c.edS, c.edT = c.edwardsST()
 	}
 	if co == nil {
 		c.Co = bigInt1
 	} else {
 		c.Co = co
+		c.coSet = true
+	}
+	c.fp = newField(c.P, c.A, c.B)
+	c.fq = newField(c.Q, zero, zero)
+	c.bk = newBaseKey(&c)
+	if err := c.validate(); err != nil {
+		return nil, err
 	}
 	return &c, nil
 }
 
+// Recently validated by NewCurve parameters sets. Least recently used
+// ones are forgotten and validated again when needed.
+var validatedCurves = newLRUCache(64)
+
+func (c *Curve) validate() error {
+	key := c.bk.key + ":" + c.B.Text(16) + ":" + c.Co.Text(16)
+	if !c.coSet {
+		key += ":implicit"
+	}
+	if _, ok := validatedCurves.get(key); ok {
+		return nil
+	}
+	if c.P.Cmp(bigInt3) <= 0 || !c.P.ProbablyPrime(20) {
+		return errorf(ErrInvalidCurve, "gogost/gost3410: P is not prime")
+	}
+	if c.Q.Cmp(bigInt3) <= 0 || !c.Q.ProbablyPrime(20) {
+		return errorf(ErrInvalidCurve, "gogost/gost3410: Q is not prime")
+	}
+	// 4*a^3 + 27*b^2 != 0 (mod p)
+	disc := big.NewInt(0).Exp(c.A, bigInt3, c.P)
+	disc.Mul(disc, bigInt4)
+	t := big.NewInt(0).Mul(c.B, c.B)
+	t.Mul(t, big.NewInt(27))
+	disc.Add(disc, t)
+	if disc.Mod(disc, c.P).Sign() == 0 {
+		return errorf(ErrInvalidCurve, "gogost/gost3410: curve is singular")
+	}
+	// |p + 1 - q*h| <= 2*sqrt(p) for the cofactor h. As some
+	// predefined curves omit their cofactor, the nearest integer one
+	// is checked if it is not set explicitly.
+	h := c.cofactor()
+	t.Mul(c.Q, h)
+	t.Sub(big.NewInt(0).Add(c.P, bigInt1), t)
+	t.Mul(t, t)
+	if t.Cmp(big.NewInt(0).Mul(c.P, bigInt4)) > 0 {
+		return errorf(ErrInvalidCurve, "gogost/gost3410: Q and cofactor do not conform to Hasse's bound")
+	}
+	if _, _, err := c.Exp(c.Q, c.X, c.Y); err == nil {
+		return errorf(ErrInvalidCurve, "gogost/gost3410: base point order is not Q")
+	}
+	validatedCurves.getOrAdd(key, func() interface{} { return struct{}{} })
+	return nil
+}
+
+// Explicitly set cofactor, or the nearest integer to (P+1)/Q otherwise.
+func (c *Curve) cofactor() *big.Int {
+	if c.coSet {
+		return c.Co
+	}
+	h := big.NewInt(0).Rsh(c.Q, 1)
+	h.Add(h, c.P)
+	h.Add(h, bigInt1)
+	return h.Div(h, c.Q)
+}
+
+// Is the point (already known to be on the curve) in the Q order
+// subgroup. Every point is for prime order curves, so only curves with
+// the cofactor greater than 1 require multiplication by Q.
+func (c *Curve) inSubgroup(x, y *big.Int) bool {
+	if c.cofactor().Cmp(bigInt1) == 0 {
+		return true
+	}
+	_, _, err := c.Exp(c.Q, x, y)
+	return err != nil
+}
+
+// Byte length of field elements, coordinates and private keys: 32 for
+// 256-bit curves and 64 for 512-bit ones.
 func (c *Curve) PointSize() int {
 	return PointSize(c.P)
 }
 
+const (
+	DigestSize256 = 32 // GOST R 34.11-94 and 256-bit Streebog
+	DigestSize512 = 64 // 512-bit Streebog
+)
+
+// Natural digest size for the curve, DigestSize256 or DigestSize512.
+// It equals the point size, so it is exactly what SignDigest and Sign
+// expect.
+func DigestSizeForCurve(c *Curve) int {
+	if c.PointSize() == 64 {
+		return DigestSize512
+	}
+	return DigestSize256
+}
+
+// Get subgroup order Q. Returned value is a copy: its mutation does not
+// affect the curve.
+func (c *Curve) Order() *big.Int {
+	return big.NewInt(0).Set(c.Q)
+}
+
+// Get field characteristic P. Returned value is a copy: its mutation
+// does not affect the curve.
+func (c *Curve) Prime() *big.Int {
+	return big.NewInt(0).Set(c.P)
+}
+
+// Get base point coordinates. Returned values are copies: their
+// mutation does not affect the curve.
+func (c *Curve) BasePoint() (x, y *big.Int) {
+	return big.NewInt(0).Set(c.X), big.NewInt(0).Set(c.Y)
+}
+
 func (c *Curve) pos(v *big.Int) {
 	if v.Cmp(zero) < 0 {
 		v.Add(v, c.P)
 	}
 }
 
+// Does the point with X and Y coordinates satisfy the curve's equation.
+func (c *Curve) contains(x, y *big.Int) bool {
+	if x.Sign() < 0 || x.Cmp(c.P) >= 0 || y.Sign() < 0 || y.Cmp(c.P) >= 0 {
+		return false
+	}
+	var r1, r2 big.Int
+	r1.Mul(y, y)
+	r1.Mod(&r1, c.P)
+	r2.Mul(x, x)
+	r2.Add(&r2, c.A)
+	r2.Mul(&r2, x)
+	r2.Add(&r2, c.B)
+	r2.Mod(&r2, c.P)
+	return r1.Cmp(&r2) == 0
+}
+
+// Is the point with X and Y coordinates in [0, p-1] range satisfies the
+// curve's equation y^2 = x^3 + ax + b mod p. nil coordinates (the
+// point at infinity) are not on the curve. It does not check that the
+// point is in the prime order subgroup, that matters on curves with
+// the cofactor greater than 1: NewPublicKey and VerifyDigest do that
+// additionally.
+func (c *Curve) IsOnCurve(x, y *big.Int) bool {
+	if x == nil || y == nil {
+		return false
+	}
+	return c.contains(x, y)
+}
+
+// Compute y for the given x: root of x^3+ax+b with the lowest bit equal
+// to odd. Nil is returned if there is no such root.
+func (c *Curve) recoverY(x *big.Int, odd bool) *big.Int {
+	rhs := big.NewInt(0).Mul(x, x)
+	rhs.Add(rhs, c.A)
+	rhs.Mul(rhs, x)
+	rhs.Add(rhs, c.B)
+	rhs.Mod(rhs, c.P)
+	y := big.NewInt(0)
+	if y.ModSqrt(rhs, c.P) == nil {
+		return nil
+	}
+	if y.Sign() == 0 {
+		if odd {
+			return nil
+		}
+		return y
+	}
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(c.P, y)
+	}
+	return y
+}
+
+// Compute y of the point with the given x, as point decompression does:
+// square root of x^3+ax+b mod p with the lowest bit equal to yBit.
+// math/big's ModSqrt is used, that has fast paths for p = 3 mod 4 and
+// p = 5 mod 8 and uses Tonelli-Shanks otherwise. Error is returned if x
+// is not in [0, p-1] range, yBit is neither 0 nor 1 or there is no
+// such point. Point is not checked to be in the prime order subgroup.
+func (c *Curve) RecoverY(x *big.Int, yBit uint) (*big.Int, error) {
+	if yBit > 1 {
+		return nil, errors.New("gogost/gost3410: yBit must be 0 or 1")
+	}
+	if x == nil || x.Sign() < 0 || x.Cmp(c.P) >= 0 {
+		return nil, errors.New("gogost/gost3410: x is out of range")
+	}
+	y := c.recoverY(x, yBit == 1)
+	if y == nil {
+		return nil, errorf(ErrPointNotOnCurve, "gogost/gost3410: x is not an abscissa of the curve point")
+	}
+	return y, nil
+}
+
 func (c *Curve) add(p1x, p1y, p2x, p2y *big.Int) {
 	var t, tx, ty big.Int
 	if p1x.Cmp(p2x) == 0 && p1y.Cmp(p2y) == 0 {
@@ -134,25 +334,180 @@ func (c *Curve) add(p1x, p1y, p2x, p2y *big.Int) {
 	p1y.Set(&ty)
 }
 
+// Add two curve's points. Point at infinity is represented with nil X
+// and Y coordinates: it is returned when the points are negation of
+// each other and it is accepted as the neutral element. Non-nil points
+// that do not belong to the curve lead to an error.
+func (c *Curve) Add(p1x, p1y, p2x, p2y *big.Int) (*big.Int, *big.Int, error) {
+	if p1x == nil || p1y == nil {
+		p1x, p1y, p2x, p2y = p2x, p2y, p1x, p1y
+	}
+	if p1x == nil || p1y == nil {
+		return nil, nil, nil
+	}
+	if !c.contains(p1x, p1y) {
+		return nil, nil, ErrPointNotOnCurve
+	}
+	if p2x == nil || p2y == nil {
+		return big.NewInt(0).Set(p1x), big.NewInt(0).Set(p1y), nil
+	}
+	if !c.contains(p2x, p2y) {
+		return nil, nil, ErrPointNotOnCurve
+	}
+	if p1x.Cmp(p2x) == 0 && (p1y.Cmp(p2y) != 0 || p1y.Sign() == 0) {
+		return nil, nil, nil
+	}
+	x := big.NewInt(0).Set(p1x)
+	y := big.NewInt(0).Set(p1y)
+	c.add(x, y, p2x, p2y)
+	return x, y, nil
+}
+
+// Are the points equal. Point at infinity (nil coordinates) equals
+// only to itself.
+func PointEqual(p1x, p1y, p2x, p2y *big.Int) bool {
+	if p1x == nil || p1y == nil || p2x == nil || p2y == nil {
+		return (p1x == nil || p1y == nil) && (p2x == nil || p2y == nil)
+	}
+	return p1x.Cmp(p2x) == 0 && p1y.Cmp(p2y) == 0
+}
+
+// Point with explicit point at infinity flag, used in Exp ladder.
+type point struct {
+	x   *big.Int
+	y   *big.Int
+	inf bool
+}
+
+func (c *Curve) pointAdd(p1, p2 *point) {
+	if p2.inf {
+		return
+	}
+	if p1.inf {
+		p1.x.Set(p2.x)
+		p1.y.Set(p2.y)
+		p1.inf = false
+		return
+	}
+	if p1.x.Cmp(p2.x) == 0 {
+		if p1.y.Cmp(p2.y) == 0 {
+			c.pointDouble(p1)
+		} else {
+			p1.inf = true
+		}
+		return
+	}
+	c.add(p1.x, p1.y, p2.x, p2.y)
+}
+
+func (c *Curve) pointDouble(p *point) {
+	if p.inf {
+		return
+	}
+	if p.y.Sign() == 0 {
+		p.inf = true
+		return
+	}
+	c.add(p.x, p.y, p.x, p.y)
+}
+
+// Scalar multiplication of the point with xS and yS coordinates by
+// the degree value. Point that does not belong to the curve leads to
+// an error.
+//
+// Curves made with NewCurve (all predefined ones) use Montgomery form
+// field arithmetic. Base point is multiplied with precomputed table,
+// with constant time lookups and complete addition formulas, other
+// points with Montgomery ladder over complete addition and doubling
+// formulas and masked point swaps. Both have no branches and memory
+// accesses depending on degree, which bit length is only revealed for
+// the degree larger than the curve's subgroup order. Curves not made
+// with NewCurve, for example constructed as a struct literal, use the
+// ladder over math/big, that is not constant time.
 func (c *Curve) Exp(degree, xS, yS *big.Int) (*big.Int, *big.Int, error) {
 	if degree.Cmp(zero) == 0 {
 		return nil, nil, errors.New("gogost/gost3410: zero degree value")
 	}
-	dg := big.NewInt(0).Sub(degree, bigInt1)
-	tx := big.NewInt(0).Set(xS)
-	ty := big.NewInt(0).Set(yS)
-	cx := big.NewInt(0).Set(xS)
-	cy := big.NewInt(0).Set(yS)
-	for dg.Cmp(zero) != 0 {
-		if dg.Bit(0) == 1 {
-			c.add(tx, ty, cx, cy)
+	if !c.contains(xS, yS) {
+		return nil, nil, ErrPointNotOnCurve
+	}
+	bits := c.Q.BitLen()
+	if degree.BitLen() > bits {
+		bits = degree.BitLen()
+	}
+	var x, y *big.Int
+	var ok bool
+	if c.fp != nil && c.fp.pBig == c.P {
+		if bits == c.Q.BitLen() && xS.Cmp(c.X) == 0 && yS.Cmp(c.Y) == 0 {
+			x, y, ok = c.expBase(degree)
+		} else {
+			x, y, ok = c.fp.exp(degree, bits, xS, yS)
+		}
+	} else {
+		x, y, ok = c.expBig(degree, bits, xS, yS)
+	}
+	if !ok {
+		return nil, nil, ErrPointAtInfinity
+	}
+	return x, y, nil
+}
+
+// Multiply (bx, by) by k, interpreted as big-endian integer, like
+// crypto/elliptic.Curve does. The point at infinity is returned as
+// (0, 0), as there. It panics if the point is not on the curve. Pay
+// attention that GOST's native scalar encoding is little-endian:
+// PrivateKey.Raw and NewPrivateKey bytes must be reversed to be used
+// here, or use ScalarFromBytesLE with Exp.
+func (c *Curve) ScalarMult(bx, by *big.Int, k []byte) (x, y *big.Int) {
+	if !c.IsOnCurve(bx, by) {
+		panic(ErrPointNotOnCurve)
+	}
+	degree := bytes2big(k)
+	if degree.Sign() == 0 {
+		return big.NewInt(0), big.NewInt(0)
+	}
+	x, y, err := c.Exp(degree, bx, by)
+	if err != nil {
+		return big.NewInt(0), big.NewInt(0)
+	}
+	return x, y
+}
+
+// Multiply the base point by big-endian k, like ScalarMult.
+func (c *Curve) ScalarBaseMult(k []byte) (x, y *big.Int) {
+	return c.ScalarMult(c.X, c.Y, k)
+}
+
+// Montgomery ladder over affine coordinates with math/big arithmetic.
+func (c *Curve) expBig(degree *big.Int, bits int, xS, yS *big.Int) (x, y *big.Int, ok bool) {
+	r0 := &point{x: big.NewInt(0), y: big.NewInt(0), inf: true}
+	r1 := &point{x: big.NewInt(0).Set(xS), y: big.NewInt(0).Set(yS)}
+	for i := bits - 1; i >= 0; i-- {
+		if degree.Bit(i) == 1 {
+			r0, r1 = r1, r0
+		}
+		c.pointAdd(r1, r0)
+		c.pointDouble(r0)
+		if degree.Bit(i) == 1 {
+			r0, r1 = r1, r0
 		}
-		dg.Rsh(dg, 1)
-		c.add(cx, cy, cx, cy)
 	}
-	return tx, ty, nil
+	if r0.inf {
+		return nil, nil, false
+	}
+	return r0.x, r0.y, true
+}
+
+// Compare optional parameters: both must be either absent or equal.
+func optionalEqual(our, their *big.Int) bool {
+	if our == nil || their == nil {
+		return our == nil && their == nil
+	}
+	return our.Cmp(their) == 0
 }
 
+// Curves are equal if their parameters are equal, Name is ignored.
+// Curve with Edwards coefficients is not equal to the one without them.
 func (our *Curve) Equal(their *Curve) bool {
 	return our.P.Cmp(their.P) == 0 &&
 		our.Q.Cmp(their.Q) == 0 &&
@@ -160,7 +515,7 @@ func (our *Curve) Equal(their *Curve) bool {
 		our.B.Cmp(their.B) == 0 &&
 		our.X.Cmp(their.X) == 0 &&
 		our.Y.Cmp(their.Y) == 0 &&
-		((our.E == nil && their.E == nil) || our.E.Cmp(their.E) == 0) &&
-		((our.D == nil && their.D == nil) || our.D.Cmp(their.D) == 0) &&
+		optionalEqual(our.E, their.E) &&
+		optionalEqual(our.D, their.D) &&
 		our.Co.Cmp(their.Co) == 0
 }
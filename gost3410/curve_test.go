@@ -0,0 +1,419 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/rand"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestCurveAdd(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	x, y, err := c.Add(c.X, c.Y, c.X, c.Y)
+	if err != nil {
+		t.FailNow()
+	}
+	x2, y2, err := c.Exp(bigInt2, c.X, c.Y)
+	if err != nil || !PointEqual(x, y, x2, y2) {
+		t.FailNow()
+	}
+	x, y, err = c.Add(x, y, c.X, c.Y)
+	if err != nil {
+		t.FailNow()
+	}
+	x2, y2, err = c.Exp(bigInt3, c.X, c.Y)
+	if err != nil || !PointEqual(x, y, x2, y2) {
+		t.FailNow()
+	}
+	if !c.contains(c.X, c.Y) || c.X.Cmp(CurveIdtc26gost341012256paramSetB().X) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestCurveAddInfinity(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	negY := big.NewInt(0).Sub(c.P, c.Y)
+	x, y, err := c.Add(c.X, c.Y, c.X, negY)
+	if err != nil || x != nil || y != nil {
+		t.FailNow()
+	}
+	x, y, err = c.Add(nil, nil, c.X, c.Y)
+	if err != nil || !PointEqual(x, y, c.X, c.Y) {
+		t.FailNow()
+	}
+	x, y, err = c.Add(c.X, c.Y, nil, nil)
+	if err != nil || !PointEqual(x, y, c.X, c.Y) {
+		t.FailNow()
+	}
+	x, y, err = c.Add(nil, nil, nil, nil)
+	if err != nil || !PointEqual(x, y, nil, nil) {
+		t.FailNow()
+	}
+	if PointEqual(c.X, c.Y, nil, nil) {
+		t.FailNow()
+	}
+}
+
+func TestCurveAddOffCurve(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	y := big.NewInt(0).Add(c.Y, bigInt1)
+	if _, _, err := c.Add(c.X, y, c.X, c.Y); err == nil {
+		t.FailNow()
+	}
+	if _, _, err := c.Add(c.X, c.Y, c.X, y); err == nil {
+		t.FailNow()
+	}
+	if _, _, err := c.Add(nil, nil, c.X, y); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestCurveExpOffCurve(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	y := big.NewInt(0).Add(c.Y, bigInt1)
+	if _, _, err := c.Exp(bigInt2, c.X, y); err == nil {
+		t.FailNow()
+	}
+}
+
+// Previous double-and-add Exp implementation
+func expReference(c *Curve, degree, xS, yS *big.Int) (*big.Int, *big.Int) {
+	dg := big.NewInt(0).Sub(degree, bigInt1)
+	tx := big.NewInt(0).Set(xS)
+	ty := big.NewInt(0).Set(yS)
+	cx := big.NewInt(0).Set(xS)
+	cy := big.NewInt(0).Set(yS)
+	for dg.Cmp(zero) != 0 {
+		if dg.Bit(0) == 1 {
+			c.add(tx, ty, cx, cy)
+		}
+		dg.Rsh(dg, 1)
+		c.add(cx, cy, cx, cy)
+	}
+	return tx, ty
+}
+
+func TestExpLadder(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdGostR34102001TestParamSet(),
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		f := func(raw [64]byte) bool {
+			degree := bytes2big(raw[:c.PointSize()])
+			degree.Mod(degree, c.Q)
+			if degree.Sign() == 0 {
+				return true
+			}
+			x, y, err := c.Exp(degree, c.X, c.Y)
+			if err != nil {
+				return false
+			}
+			xRef, yRef := expReference(c, degree, c.X, c.Y)
+			return PointEqual(x, y, xRef, yRef)
+		}
+		if err := quick.Check(f, nil); err != nil {
+			t.Error(err)
+		}
+		for _, degree := range []*big.Int{bigInt1, bigInt2, bigInt3, bigInt4} {
+			x, y, err := c.Exp(degree, c.X, c.Y)
+			if err != nil {
+				t.FailNow()
+			}
+			xRef, yRef := expReference(c, degree, c.X, c.Y)
+			if !PointEqual(x, y, xRef, yRef) {
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestExpOrder(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012256paramSetB(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		if _, _, err := c.Exp(c.Q, c.X, c.Y); err == nil {
+			t.Fatal(c.Name, "Q*G is not infinity")
+		}
+		x, y, err := c.Exp(big.NewInt(0).Sub(c.Q, bigInt1), c.X, c.Y)
+		if err != nil || x.Cmp(c.X) != 0 || y.Cmp(big.NewInt(0).Sub(c.P, c.Y)) != 0 {
+			t.Fatal(c.Name, "(Q-1)*G != -G")
+		}
+		x, y, err = c.Exp(big.NewInt(0).Add(c.Q, bigInt1), c.X, c.Y)
+		if err != nil || !PointEqual(x, y, c.X, c.Y) {
+			t.Fatal(c.Name, "(Q+1)*G != G")
+		}
+		if x, y, err = c.Add(x, y, c.X, big.NewInt(0).Sub(c.P, c.Y)); err != nil || x != nil || y != nil {
+			t.Fatal(c.Name, "G+(-G) is not infinity")
+		}
+		prv := &PrivateKey{c, big.NewInt(0).Set(c.Q)}
+		if _, err = prv.PublicKey(); err == nil {
+			t.Fatal(c.Name, "public key for Q")
+		}
+		if _, err = NewPrivateKey(c, prv.Raw()); err == nil {
+			t.Fatal(c.Name, "private key equal to Q")
+		}
+	}
+}
+
+func TestCurveAccessors(t *testing.T) {
+	c := CurveIdtc26gost341012512paramSetA()
+	ref := CurveIdtc26gost341012512paramSetA()
+	q, p := c.Order(), c.Prime()
+	x, y := c.BasePoint()
+	if q.Cmp(ref.Q) != 0 || p.Cmp(ref.P) != 0 || !PointEqual(x, y, ref.X, ref.Y) {
+		t.FailNow()
+	}
+	for _, v := range []*big.Int{q, p, x, y} {
+		v.SetInt64(1)
+	}
+	if !c.Equal(ref) {
+		t.FailNow()
+	}
+}
+
+func TestDigestSizeForCurve(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdGostR34102001TestParamSet(),
+		CurveIdGostR34102001CryptoProAParamSet(),
+		CurveIdtc26gost341012256paramSetA(),
+	} {
+		if c.PointSize() != 32 || DigestSizeForCurve(c) != DigestSize256 {
+			t.Fatal(c.Name)
+		}
+	}
+	for _, c := range []*Curve{
+		CurveIdtc26gost34102012512paramSetTest(),
+		CurveIdtc26gost341012512paramSetA(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		if c.PointSize() != 64 || DigestSizeForCurve(c) != DigestSize512 {
+			t.Fatal(c.Name)
+		}
+	}
+}
+
+func TestNewCurveValidation(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	if _, err := NewCurve(c.P, c.Q, c.A, c.B, c.X, c.Y, c.E, c.D, c.Co); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCurve(c.P, c.Q, c.A, c.B, c.X, c.Y, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	otherQ := big.NewInt(0).Add(c.Q, bigInt2)
+	for !otherQ.ProbablyPrime(20) {
+		otherQ.Add(otherQ, bigInt2)
+	}
+	one := big.NewInt(1)
+	for name, params := range map[string][9]*big.Int{
+		"nil":           {c.P, nil, c.A, c.B, c.X, c.Y, nil, nil, nil},
+		"off-curve":     {c.P, c.Q, c.A, c.B, c.X, big.NewInt(0).Add(c.Y, one), nil, nil, nil},
+		"composite P":   {big.NewInt(0).Add(c.P, one), c.Q, c.A, c.B, c.X, c.Y, nil, nil, nil},
+		"composite Q":   {c.P, big.NewInt(0).Add(c.Q, one), c.A, c.B, c.X, c.Y, nil, nil, nil},
+		"wrong order":   {c.P, otherQ, c.A, c.B, c.X, c.Y, nil, nil, nil},
+		"cofactor":      {c.P, c.Q, c.A, c.B, c.X, c.Y, nil, nil, big.NewInt(5)},
+		"zero cofactor": {c.P, c.Q, c.A, c.B, c.X, c.Y, nil, nil, big.NewInt(0)},
+		"singular":      {c.P, c.Q, big.NewInt(0), big.NewInt(0), one, one, nil, nil, nil},
+	} {
+		if _, err := NewCurve(
+			params[0], params[1], params[2], params[3], params[4],
+			params[5], params[6], params[7], params[8],
+		); err == nil {
+			t.Fatal(name)
+		}
+	}
+}
+
+func TestCurveRecoverY(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		x7, y7, err := c.Exp(big.NewInt(7), c.X, c.Y)
+		if err != nil {
+			t.FailNow()
+		}
+		for _, p := range [][2]*big.Int{{c.X, c.Y}, {x7, y7}} {
+			y, err := c.RecoverY(p[0], p[1].Bit(0))
+			if err != nil || y.Cmp(p[1]) != 0 {
+				t.Fatal(c.Name, err)
+			}
+			y, err = c.RecoverY(p[0], 1-p[1].Bit(0))
+			if err != nil || y.Cmp(big.NewInt(0).Sub(c.P, p[1])) != 0 {
+				t.Fatal(c.Name, err)
+			}
+		}
+		x := big.NewInt(0)
+		for {
+			if _, err = c.RecoverY(x, 0); err != nil {
+				break
+			}
+			x.Add(x, bigInt1)
+		}
+		if !errors.Is(err, ErrPointNotOnCurve) {
+			t.Fatal(c.Name, err)
+		}
+		if _, err = c.RecoverY(c.P, 0); err == nil {
+			t.Fatal(c.Name)
+		}
+		if _, err = c.RecoverY(big.NewInt(-1), 0); err == nil {
+			t.Fatal(c.Name)
+		}
+		if _, err = c.RecoverY(c.X, 2); err == nil {
+			t.Fatal(c.Name)
+		}
+	}
+}
+
+func TestCurveIsOnCurve(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		if !c.IsOnCurve(c.X, c.Y) {
+			t.Fatal(c.Name)
+		}
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		if !pub.IsOnCurve() {
+			t.Fatal(c.Name)
+		}
+		yNext := big.NewInt(0).Add(pub.Y, bigInt1)
+		yNext.Mod(yNext, c.P)
+		for _, p := range [][2]*big.Int{
+			{pub.X, yNext},
+			{big.NewInt(0).Add(pub.X, c.P), pub.Y},
+			{pub.X, big.NewInt(0).Sub(pub.Y, c.P)},
+			{nil, pub.Y},
+			{pub.X, nil},
+		} {
+			if c.IsOnCurve(p[0], p[1]) {
+				t.Fatal(c.Name, p)
+			}
+			if (&PublicKey{c, p[0], p[1]}).IsOnCurve() {
+				t.Fatal(c.Name, p)
+			}
+		}
+	}
+}
+
+func TestCurveScalarMult(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		k := make([]byte, c.PointSize())
+		rand.Read(k)
+		xRef, yRef, err := c.Exp(bytes2big(k), c.X, c.Y)
+		if err != nil {
+			t.FailNow()
+		}
+		x, y := c.ScalarBaseMult(k)
+		if !PointEqual(x, y, xRef, yRef) {
+			t.Fatal(c.Name)
+		}
+		k2 := []byte{0x01, 0x00, 0x07}
+		x2Ref, y2Ref, err := c.Exp(big.NewInt(0x010007), x, y)
+		if err != nil {
+			t.FailNow()
+		}
+		x2, y2 := c.ScalarMult(x, y, k2)
+		if !PointEqual(x2, y2, x2Ref, y2Ref) {
+			t.Fatal(c.Name)
+		}
+		x, y = c.ScalarBaseMult(nil)
+		if x.Sign() != 0 || y.Sign() != 0 {
+			t.Fatal(c.Name)
+		}
+		x, y = c.ScalarBaseMult(c.Q.Bytes())
+		if x.Sign() != 0 || y.Sign() != 0 {
+			t.Fatal(c.Name)
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal(c.Name)
+				}
+			}()
+			c.ScalarMult(c.X, big.NewInt(0).Add(c.Y, bigInt1), k)
+		}()
+	}
+}
+
+func TestCurveEqualWithoutEdwards(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	noEd, err := NewCurve(c.P, c.Q, c.A, c.B, c.X, c.Y, nil, nil, c.Co)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if noEd.Equal(c) || c.Equal(noEd) || !noEd.Equal(noEd) {
+		t.FailNow()
+	}
+	if _, ok := noEd.OID(); ok {
+		t.FailNow()
+	}
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	prvNoEd, err := NewPrivateKey(noEd, prv.Raw())
+	if err != nil {
+		t.FailNow()
+	}
+	pubNoEd, err := prvNoEd.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	if pub.Equal(pubNoEd) || prv.Equal(prvNoEd) {
+		t.FailNow()
+	}
+	if _, err = prv.KEK(pubNoEd, bigInt1); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestCurveOID(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	oid, ok := c.OID()
+	if !ok || !oid.Equal(asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 2}) {
+		t.FailNow()
+	}
+	// Name is not trusted: parameters decide
+	c.Name = CurveIdtc26gost341012512paramSetA().Name
+	oid, ok = c.OID()
+	if !ok || !oid.Equal(asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 1}) {
+		t.Fatal(oid)
+	}
+	unknown, err := NewCurve(c.P, c.Q, c.A, c.B, c.X, c.Y, nil, nil, nil)
+	if err != nil {
+		t.FailNow()
+	}
+	unknown.Name = c.Name
+	if _, ok = unknown.OID(); !ok {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,122 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/hmac"
+	"hash"
+	"math/big"
+
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+)
+
+// RFC 6979-like deterministic k values generator. It acts as an
+// io.Reader giving Curve.PointSize()-sized candidates from [1, Q-1]
+// range one after another, as SignDigest expects it.
+type rfc6979 struct {
+	q       *big.Int
+	size    int
+	newHash func() hash.Hash
+	v       []byte
+	mac     hash.Hash
+	buf     []byte
+	next    bool
+}
+
+func (r *rfc6979) rekey(k []byte) {
+	r.mac = hmac.New(r.newHash, k)
+}
+
+func (r *rfc6979) hmac(data ...[]byte) []byte {
+	r.mac.Reset()
+	for _, d := range data {
+		r.mac.Write(d)
+	}
+	return r.mac.Sum(nil)
+}
+
+func (r *rfc6979) bits2int(b []byte) *big.Int {
+	v := bytes2big(b)
+	if excess := len(b)*8 - r.q.BitLen(); excess > 0 {
+		v.Rsh(v, uint(excess))
+	}
+	return v
+}
+
+func newRFC6979(prv *PrivateKey, digest []byte) *rfc6979 {
+	newHash := gost34112012256.New
+	if prv.C.PointSize() == 64 {
+		newHash = gost34112012512.New
+	}
+	r := rfc6979{q: prv.C.Q, size: prv.C.PointSize(), newHash: newHash}
+	rlen := (r.q.BitLen() + 7) / 8
+	x := pad(prv.Key.Bytes(), rlen)
+	h := r.bits2int(digest)
+	h.Mod(h, r.q)
+	h1 := pad(h.Bytes(), rlen)
+	hLen := newHash().Size()
+	r.v = make([]byte, hLen)
+	for i := 0; i < hLen; i++ {
+		r.v[i] = 0x01
+	}
+	r.rekey(make([]byte, hLen))
+	r.rekey(r.hmac(r.v, []byte{0x00}, x, h1))
+	r.v = r.hmac(r.v)
+	r.rekey(r.hmac(r.v, []byte{0x01}, x, h1))
+	r.v = r.hmac(r.v)
+	return &r
+}
+
+func (r *rfc6979) candidate() *big.Int {
+	for {
+		if r.next {
+			r.rekey(r.hmac(r.v, []byte{0x00}))
+			r.v = r.hmac(r.v)
+		}
+		r.next = true
+		t := make([]byte, 0, r.size)
+		for len(t)*8 < r.q.BitLen() {
+			r.v = r.hmac(r.v)
+			t = append(t, r.v...)
+		}
+		k := r.bits2int(t)
+		if k.Sign() > 0 && k.Cmp(r.q) < 0 {
+			return k
+		}
+	}
+}
+
+func (r *rfc6979) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.buf) == 0 {
+			r.buf = pad(r.candidate().Bytes(), r.size)
+		}
+		c := copy(p[n:], r.buf)
+		r.buf = r.buf[c:]
+		n += c
+	}
+	return n, nil
+}
+
+// Sign the digest with k value deterministically derived from the
+// private key and the digest itself, similarly to RFC 6979 with
+// HMAC-Streebog of the curve's size. The same digest always gives the
+// same signature, no entropy source is needed.
+func (prv *PrivateKey) SignDigestDeterministic(digest []byte) ([]byte, error) {
+	return prv.SignDigest(digest, newRFC6979(prv, digest))
+}
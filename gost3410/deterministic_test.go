@@ -0,0 +1,137 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestSignDigestDeterministic(t *testing.T) {
+	f := func(c *Curve) {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		digest := make([]byte, c.PointSize())
+		rand.Read(digest)
+		sign1, err := prv.SignDigestDeterministic(digest)
+		if err != nil {
+			t.FailNow()
+		}
+		sign2, err := prv.SignDigestDeterministic(digest)
+		if err != nil {
+			t.FailNow()
+		}
+		if bytes.Compare(sign1, sign2) != 0 {
+			t.FailNow()
+		}
+		valid, err := pub.VerifyDigest(digest, sign1)
+		if err != nil || !valid {
+			t.FailNow()
+		}
+		digest[0] ^= 0x01
+		sign2, err = prv.SignDigestDeterministic(digest)
+		if err != nil {
+			t.FailNow()
+		}
+		if bytes.Compare(sign1, sign2) == 0 {
+			t.FailNow()
+		}
+		valid, err = pub.VerifyDigest(digest, sign2)
+		if err != nil || !valid {
+			t.FailNow()
+		}
+	}
+	f(CurveIdGostR34102001TestParamSet())
+	f(CurveIdtc26gost341012256paramSetA())
+	f(CurveIdtc26gost341012512paramSetA())
+}
+
+func TestRFC6979Candidates(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	r := newRFC6979(prv, make([]byte, 32))
+	seen := make(map[string]bool)
+	for i := 0; i < 16; i++ {
+		k := make([]byte, c.PointSize())
+		if _, err = r.Read(k); err != nil {
+			t.FailNow()
+		}
+		if bytes2big(k).Cmp(c.Q) >= 0 || seen[string(k)] {
+			t.FailNow()
+		}
+		seen[string(k)] = true
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("no entropy")
+}
+
+func TestSignWithoutEntropy(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	digest := make([]byte, c.PointSize())
+	rand.Read(digest)
+	k, err := rand.Int(rand.Reader, c.Q)
+	if err != nil {
+		t.FailNow()
+	}
+	k.Add(k, bigInt1).Mod(k, c.Q)
+	orig := rand.Reader
+	rand.Reader = failingReader{}
+	defer func() { rand.Reader = orig }()
+	det1, err := prv.SignDigestDeterministic(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	det2, err := prv.SignDigestDeterministic(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(det1, det2) != 0 {
+		t.FailNow()
+	}
+	withK, err := prv.SignDigestWithK(digest, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sign := range [][]byte{det1, withK} {
+		valid, err := pub.VerifyDigest(digest, sign)
+		if err != nil || !valid {
+			t.FailNow()
+		}
+	}
+}
@@ -2,4 +2,16 @@
 // GOST R 34.10-2012 (RFC 7091) signature algorithms and
 // VKO GOST R 34.10-2001 (RFC 4357),
 // VKO GOST R 34.10-2012 (RFC 7836) key agreement algorithms.
+//
+// Curve, PublicKey and PrivateKey are safe for concurrent use by
+// multiple goroutines as long as their fields are not modified:
+// verification, signing, key agreement and points arithmetic only read
+// them. Shared precomputed values (base point tables, twisted Edwards
+// conversion parameters) are computed once under synchronization.
+//
+// Digest is interpreted as big-endian integer e reduced modulo Q. As
+// GOST R 34.10 requires, zero e is replaced with one both when signing
+// and verifying, so all-zero digest (and digest being multiple of Q)
+// is signed as the digest equal to one. Other implementations must do
+// the same for signatures of such digests to interoperate.
 package gost3410
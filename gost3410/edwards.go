@@ -19,31 +19,51 @@ import (
 	"math/big"
 )
 
+// Twisted Edwards curve e*u^2 + v^2 = 1 + d*u^2*v^2 is birationally
+// equivalent to Weierstrass y^2 = x^3 + a*x + b one, where (R 1323565.1.024-2019):
+//
+//	s = (e - d) / 4, t = (e + d) / 6,
+//	x = s*(1 + v)/(1 - v) + t, y = s*(1 + v)/((1 - v)*u),
+//	u = (x - t)/y, v = (x - t - s)/(x - t + s).
+//
+// Curves with E and D (like id-tc26-gost-3410-2012-256-paramSetA) are
+// stored and used in Weierstrass form: Exp, signing, verification and
+// VKO work with X/Y coordinates, so keys and signatures interoperate with
+// the standard ones. Conversion is needed only for points obtained in
+// U/V form.
+
+// Is the curve having twisted Edwards form coefficients.
 func (c *Curve) IsEdwards() bool {
 	return c.E != nil
 }
 
+// Get s and t parameters of the birational equivalence. They are
+// precomputed by NewCurve, so the curve is not modified here.
 func (c *Curve) EdwardsST() (*big.Int, *big.Int) {
-	if c.edS != nil {
+	if c.edS != nil && c.edT != nil {
 		return c.edS, c.edT
 	}
-	c.edS = big.NewInt(0)
-	c.edS.Set(c.E)
-	c.edS.Sub(c.edS, c.D)
-	c.pos(c.edS)
+	return c.edwardsST()
+}
+
+func (c *Curve) edwardsST() (edS, edT *big.Int) {
+	edS = big.NewInt(0)
+	edS.Set(c.E)
+	edS.Sub(edS, c.D)
+	c.pos(edS)
 	var t big.Int
 	t.SetUint64(4)
 	t.ModInverse(&t, c.P)
-	c.edS.Mul(c.edS, &t)
-	c.edS.Mod(c.edS, c.P)
-	c.edT = big.NewInt(0)
-	c.edT.Set(c.E)
-	c.edT.Add(c.edT, c.D)
+	edS.Mul(edS, &t)
+	edS.Mod(edS, c.P)
+	edT = big.NewInt(0)
+	edT.Set(c.E)
+	edT.Add(edT, c.D)
 	t.SetUint64(6)
 	t.ModInverse(&t, c.P)
-	c.edT.Mul(c.edT, &t)
-	c.edT.Mod(c.edT, c.P)
-	return c.edS, c.edT
+	edT.Mul(edT, &t)
+	edT.Mod(edT, c.P)
+	return
 }
 
 // Convert Weierstrass X,Y coordinates to twisted Edwards U,V
@@ -0,0 +1,146 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/cipher"
+	"encoding/asn1"
+	"errors"
+	"io"
+
+	"go.cypherpunks.ru/gogost/v5/gost28147"
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+)
+
+// Size of envelope's content authentication tag.
+const EnvelopeTagSize = 8
+
+const (
+	envelopeEncLabel = "gogost/gost3410 envelope encryption"
+	envelopeMACLabel = "gogost/gost3410 envelope authentication"
+)
+
+// DER encoded envelope made by Seal:
+//
+//	Envelope ::= SEQUENCE {
+//	    ephemeralPublicKey OCTET STRING, -- PublicKey.Raw
+//	    ukm OCTET STRING (SIZE (8)),
+//	    encryptedKey OCTET STRING (SIZE (36)), -- CEK_ENC || CEK_MAC
+//	    iv OCTET STRING (SIZE (8)),
+//	    encryptedContent OCTET STRING -- ciphertext || tag
+//	}
+//
+// First three fields are the same as GostR3410-TransportParameters and
+// Gost28147-89-EncryptedKey of CMS KeyTransRecipientInfo (RFC 4490)
+// hold, so they can be moved into EnvelopedData as is. CMS itself has
+// no content integrity, so, unlike it, content is encrypted with
+// gost28147's EtM AEAD, with keys derived from CEK and with the first
+// three fields DER encodings as an additional data.
+type envelope struct {
+	EphemeralPublicKey []byte
+	UKM                []byte
+	EncryptedKey       []byte
+	IV                 []byte
+	EncryptedContent   []byte
+}
+
+// Create content AEAD with encryption and MAC keys derived from CEK
+// with KDF_GOSTR3411_2012_256 (RFC 7836 4.5), using IV as a seed.
+func envelopeAEAD(cek, iv []byte) (cipher.AEAD, error) {
+	kdf := gost34112012256.NewKDF(cek)
+	return gost28147.NewEtM(
+		kdf.Derive(nil, []byte(envelopeEncLabel), iv),
+		kdf.Derive(nil, []byte(envelopeMACLabel), iv),
+		gost28147.SboxDefault,
+		EnvelopeTagSize,
+	)
+}
+
+func (env *envelope) ad() []byte {
+	ad, err := asn1.Marshal([][]byte{env.EphemeralPublicKey, env.UKM, env.EncryptedKey})
+	if err != nil {
+		panic(err)
+	}
+	return ad
+}
+
+// Encrypt plaintext to recipient's public key. Ephemeral key pair on
+// recipient's curve is generated, KEK is derived from it with VKO and
+// random UKM, as WrapKey2012 does, and random 32-byte CEK is wrapped
+// with it. Content is encrypted and authenticated with GOST 28147-89 EtM
+// AEAD (CNT mode and MAC) under keys derived from CEK. All randomness
+// is read from rand.
+func Seal(recipient *PublicKey, plaintext []byte, rand io.Reader) ([]byte, error) {
+	eph, err := GenPrivateKey(recipient.C, rand)
+	if err != nil {
+		return nil, err
+	}
+	ephPub, err := eph.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 8+gost28147.KeySize+gost28147.BlockSize)
+	if _, err = io.ReadFull(rand, buf); err != nil {
+		return nil, err
+	}
+	ukm, cek, iv := buf[:8], buf[8:8+gost28147.KeySize], buf[8+gost28147.KeySize:]
+	wrapped, err := WrapKey2012(eph, recipient, ukm, cek)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := envelopeAEAD(cek, iv)
+	if err != nil {
+		return nil, err
+	}
+	env := envelope{
+		EphemeralPublicKey: ephPub.Raw(),
+		UKM:                ukm,
+		EncryptedKey:       wrapped,
+		IV:                 iv,
+	}
+	env.EncryptedContent = aead.Seal(nil, iv, plaintext, env.ad())
+	return asn1.Marshal(env)
+}
+
+// Decrypt envelope made by Seal with recipient's private key. Error is
+// returned if envelope is malformed, was made for another key or was
+// altered.
+func Open(prv *PrivateKey, sealed []byte) ([]byte, error) {
+	var env envelope
+	rest, err := asn1.Unmarshal(sealed, &env)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errorf(ErrTrailingData, "gogost/gost3410: trailing data after envelope")
+	}
+	if len(env.IV) != gost28147.BlockSize {
+		return nil, errors.New("gogost/gost3410: invalid envelope IV length")
+	}
+	ephPub, err := NewPublicKey(prv.C, env.EphemeralPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := UnwrapKey2012(prv, ephPub, env.UKM, env.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := envelopeAEAD(cek, env.IV)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, env.IV, env.EncryptedContent, env.ad())
+}
@@ -0,0 +1,89 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestEnvelope(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdGostR34102001CryptoProXchAParamSet(),
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		for _, size := range []int{0, 1, 31, 1025, 3000} {
+			pt := make([]byte, size)
+			rand.Read(pt)
+			sealed, err := Seal(pub, pt, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			opened, err := Open(prv, sealed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Compare(opened, pt) != 0 {
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestEnvelopeTamper(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	sealed, err := Seal(pub, []byte("some secret message"), rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	for i := 0; i < len(sealed); i++ {
+		for _, bit := range []byte{0x01, 0x80} {
+			tampered := append([]byte{}, sealed...)
+			tampered[i] ^= bit
+			if pt, err := Open(prv, tampered); err == nil {
+				t.Fatal(i, bit, pt)
+			}
+		}
+	}
+	if _, err = Open(prv, append(sealed, 0)); err == nil {
+		t.FailNow()
+	}
+	other, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	if _, err = Open(other, sealed); err == nil {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,61 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors that can be checked with errors.Is. Some of the returned
+// errors have more specific messages, but still match them.
+var (
+	ErrZeroPrivateKey         = errors.New("gogost/gost3410: zero private key")
+	ErrPrivateKeyOutOfRange   = errors.New("gogost/gost3410: private key is out of range")
+	ErrInvalidKeyLength       = errors.New("gogost/gost3410: invalid key length")
+	ErrInvalidUKMLength       = errors.New("gogost/gost3410: len(ukm) != 8")
+	ErrZeroUKM                = errors.New("gogost/gost3410: zero ukm")
+	ErrInvalidDigestLength    = errors.New("gogost/gost3410: invalid digest length")
+	ErrDigestSizeMismatch     = errors.New("gogost/gost3410: digest size does not match the curve")
+	ErrInvalidSignatureLength = errors.New("gogost/gost3410: invalid signature length")
+	ErrPointNotOnCurve        = errors.New("gogost/gost3410: point is not on the curve")
+	ErrPointNotInSubgroup     = errors.New("gogost/gost3410: point is not in the prime order subgroup")
+	ErrPointAtInfinity        = errors.New("gogost/gost3410: point at infinity")
+	ErrDifferentCurve         = errors.New("gogost/gost3410: public key is on different curve")
+	ErrUnknownCurve           = errors.New("gogost/gost3410: unknown curve")
+	ErrInvalidCurve           = errors.New("gogost/gost3410: invalid curve parameters")
+	ErrTrailingData           = errors.New("gogost/gost3410: trailing data")
+	ErrKReuse                 = errors.New("gogost/gost3410: k reuse detected")
+	ErrTruncatedData          = errors.New("gogost/gost3410: truncated data")
+)
+
+// Error with its own message, matching the sentinel one with errors.Is.
+type sentinelError struct {
+	sentinel error
+	msg      string
+}
+
+func (err *sentinelError) Error() string {
+	return err.msg
+}
+
+func (err *sentinelError) Unwrap() error {
+	return err.sentinel
+}
+
+func errorf(sentinel error, format string, a ...interface{}) error {
+	return &sentinelError{sentinel, fmt.Sprintf(format, a...)}
+}
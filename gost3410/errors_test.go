@@ -0,0 +1,159 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := make([]byte, 32)
+	rand.Read(digest)
+	sig, err := prv.SignDigest(digest, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offCurve := &PublicKey{c, pub.X, big.NewInt(0).Add(pub.Y, bigInt1)}
+	other := CurveIdtc26gost341012256paramSetC()
+	prvOther, _ := GenPrivateKey(other, rand.Reader)
+	pubOther, _ := prvOther.PublicKey()
+	smallX, smallY := smallOrderPoint(t, CurveIdtc26gost341012256paramSetA())
+	small := &PublicKey{CurveIdtc26gost341012256paramSetA(), smallX, smallY}
+	for _, v := range []struct {
+		name     string
+		sentinel error
+		msg      string
+		f        func() error
+	}{
+		{"zero key", ErrZeroPrivateKey, "gogost/gost3410: zero private key", func() error {
+			_, err := NewPrivateKey(c, make([]byte, 32))
+			return err
+		}},
+		{"key not less than Q", ErrPrivateKeyOutOfRange, "gogost/gost3410: private key is not less than Q", func() error {
+			raw := pad(c.Q.Bytes(), 32)
+			reverse(raw)
+			_, err := NewPrivateKey(c, raw)
+			return err
+		}},
+		{"key length", ErrInvalidKeyLength, "gogost/gost3410: len(key) != 32", func() error {
+			_, err := NewPrivateKey(c, make([]byte, 31))
+			return err
+		}},
+		{"raw key length", ErrInvalidKeyLength, "gogost/gost3410: raw private key must be 32 bytes, got 33", func() error {
+			_, err := ParsePrivateKeyRaw(c, make([]byte, 33))
+			return err
+		}},
+		{"public key length", ErrInvalidKeyLength, "gogost/gost3410: len(key) != 64", func() error {
+			_, err := NewPublicKey(c, make([]byte, 63))
+			return err
+		}},
+		{"UKM length", ErrInvalidUKMLength, "gogost/gost3410: len(ukm) != 8", func() error {
+			_, err := WrapKey2012(prv, pub, make([]byte, 7), make([]byte, 32))
+			return err
+		}},
+		{"VKO UKM length", ErrInvalidUKMLength, "gogost/gost3410: len(ukm) not in 1..32", func() error {
+			_, err := prv.KEKVKO(pub, nil, gost34112012256.New)
+			return err
+		}},
+		{"zero UKM", ErrZeroUKM, "gogost/gost3410: zero ukm", func() error {
+			_, err := prv.KEKVKO(pub, make([]byte, 8), gost34112012256.New)
+			return err
+		}},
+		{"digest length", ErrInvalidDigestLength, "gogost/gost3410: len(digest) > 32", func() error {
+			_, err := prv.Sign(rand.Reader, make([]byte, 33), crypto.Hash(0))
+			return err
+		}},
+		{"signature length", ErrInvalidSignatureLength, "gogost/gost3410: len(signature) != 64", func() error {
+			_, err := pub.VerifyDigest(digest, sig[1:])
+			return err
+		}},
+		{"signature struct length", ErrInvalidSignatureLength, "gogost/gost3410: invalid signature length", func() error {
+			_, err := NewSignature(c, sig[1:])
+			return err
+		}},
+		{"point not on curve", ErrPointNotOnCurve, "gogost/gost3410: point is not on the curve", func() error {
+			_, err := NewPublicKey(c, offCurve.Raw())
+			return err
+		}},
+		{"peer not on curve", ErrPointNotOnCurve, "gogost/gost3410: public key is not on the curve", func() error {
+			_, err := prv.KEK(offCurve, bigInt1)
+			return err
+		}},
+		{"not in subgroup", ErrPointNotInSubgroup, "gogost/gost3410: point is not in the prime order subgroup", func() error {
+			_, err := NewPublicKey(small.C, small.Raw())
+			return err
+		}},
+		{"point at infinity", ErrPointAtInfinity, "gogost/gost3410: point at infinity", func() error {
+			_, _, err := c.Exp(c.Q, c.X, c.Y)
+			return err
+		}},
+		{"peer at infinity", ErrPointAtInfinity, "gogost/gost3410: public key is the point at infinity", func() error {
+			_, err := pub.VerifyDigest(digest, sig)
+			if err != nil {
+				return err
+			}
+			_, err = (&PublicKey{c, nil, nil}).VerifyDigest(digest, sig)
+			return err
+		}},
+		{"different curve", ErrDifferentCurve, "gogost/gost3410: public key is on different curve", func() error {
+			_, err := prv.KEK(pubOther, bigInt1)
+			return err
+		}},
+		{"digest size", ErrDigestSizeMismatch, "gogost/gost3410: digest size does not match the curve", func() error {
+			_, err := prv.SignStreebog512([]byte("msg"), rand.Reader)
+			return err
+		}},
+		{"unknown curve", ErrUnknownCurve, "gogost/gost3410: unknown curve", func() error {
+			unknown := *c
+			unknown.Name = "unknown"
+			unknown.B = big.NewInt(0).Add(c.B, bigInt1)
+			_, err := algorithmIdentifier(&unknown)
+			return err
+		}},
+		{"invalid curve", ErrInvalidCurve, "gogost/gost3410: base point is not on the curve", func() error {
+			_, err := NewCurve(c.P, c.Q, c.A, c.B, c.X, big.NewInt(0).Add(c.Y, bigInt1), nil, nil, nil)
+			return err
+		}},
+		{"trailing data", ErrTrailingData, "gogost/gost3410: trailing data after signature", func() error {
+			der, _ := MarshalSignatureDER(sig)
+			_, err := UnmarshalSignatureDER(append(der, 0))
+			return err
+		}},
+	} {
+		err := v.f()
+		if !errors.Is(err, v.sentinel) {
+			t.Fatalf("%s: %v is not %v", v.name, err, v.sentinel)
+		}
+		if err.Error() != v.msg {
+			t.Fatalf("%s: message %q changed", v.name, err.Error())
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Maximal number of 64-bit limbs of field element: enough for 512-bit
+// primes.
+const feLimbs = 8
+
+// Prime field element in Montgomery form, little-endian limbs.
+type fe [feLimbs]uint64
+
+// Prime field with precomputed Montgomery constants, used to multiply
+// points in Jacobian coordinates without math/big allocations and
+// per-operation modular inversion. Curve's A and 3*B coefficients are
+// kept here too, for the doubling and complete addition formulas.
+// Reductions in mul, add and sub are made without branches.
+type field struct {
+	n    int // number of used limbs
+	pBig *big.Int
+	p    fe     // field prime
+	pInv uint64 // -p^-1 mod 2^64
+	rr   fe     // R^2 mod p
+	one  fe     // R mod p, that is 1 in Montgomery form
+	pm2  *big.Int
+	a    fe
+	b3   fe
+}
+
+func feFromBig(x *big.Int) (r fe) {
+	b := x.Bytes()
+	for i := 0; i < len(b); i++ {
+		r[i/8] |= uint64(b[len(b)-1-i]) << (8 * uint(i%8))
+	}
+	return
+}
+
+// Convert non-negative value to limbs directly from its words, without
+// intermediate byte slice or big.Int copies of possibly secret value.
+// Value must fit in feLimbs limbs.
+func feFromWords(x *big.Int) (r fe) {
+	for i, w := range x.Bits() {
+		if bits.UintSize == 64 {
+			r[i] = uint64(w)
+		} else {
+			r[i/2] |= uint64(w) << (32 * uint(i%2))
+		}
+	}
+	return
+}
+
+// Overwrite element with zeros.
+func (r *fe) clear() {
+	for i := range r {
+		r[i] = 0
+	}
+}
+
+func (r *fe) big(n int) *big.Int {
+	b := make([]byte, n*8)
+	for i := 0; i < n*8; i++ {
+		b[len(b)-1-i] = byte(r[i/8] >> (8 * uint(i%8)))
+	}
+	return bytes2big(b)
+}
+
+// Create field for odd prime p of at most 512 bits. nil is returned for
+// unsupported values, math/big arithmetic is used then.
+func newField(p, a, b *big.Int) *field {
+	if p == nil || a == nil || b == nil || p.Sign() <= 0 || p.Bit(0) == 0 || p.BitLen() > 64*feLimbs {
+		return nil
+	}
+	f := field{n: (p.BitLen() + 63) / 64, pBig: p, p: feFromBig(p)}
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.p[0]*inv
+	}
+	f.pInv = -inv
+	r := big.NewInt(0).Lsh(bigInt1, uint(64*f.n))
+	f.one = feFromBig(big.NewInt(0).Mod(r, p))
+	f.rr = feFromBig(big.NewInt(0).Mod(r.Mul(r, r), p))
+	f.pm2 = big.NewInt(0).Sub(p, bigInt2)
+	f.a = f.fromBig(a)
+	f.b3 = f.fromBig(big.NewInt(0).Mul(b, bigInt3))
+	return &f
+}
+
+// Convert value to Montgomery form.
+func (f *field) fromBig(x *big.Int) fe {
+	var t big.Int
+	t.Mod(x, f.pBig)
+	r := feFromBig(&t)
+	f.mul(&r, &r, &f.rr)
+	return r
+}
+
+// Convert value already reduced modulo p to Montgomery form without
+// math/big operations.
+func (f *field) fromReduced(x *big.Int) fe {
+	r := feFromWords(x)
+	f.mul(&r, &r, &f.rr)
+	return r
+}
+
+// Convert value from Montgomery form.
+func (f *field) toBig(x *fe) *big.Int {
+	var one fe
+	one[0] = 1
+	var r fe
+	f.mul(&r, x, &one)
+	return r.big(f.n)
+}
+
+// z = x if mask is all ones, y if it is zero, in constant time.
+func (f *field) selectFe(z, x, y *fe, mask uint64) {
+	for i := 0; i < f.n; i++ {
+		z[i] = (x[i] & mask) | (y[i] &^ mask)
+	}
+}
+
+func (f *field) isZero(x *fe) bool {
+	var acc uint64
+	for i := 0; i < f.n; i++ {
+		acc |= x[i]
+	}
+	return acc == 0
+}
+
+func (f *field) equal(x, y *fe) bool {
+	var acc uint64
+	for i := 0; i < f.n; i++ {
+		acc |= x[i] ^ y[i]
+	}
+	return acc == 0
+}
+
+// z = x*y*R^-1 mod p, coarsely integrated operand scanning.
+func (f *field) mul(z, x, y *fe) {
+	var t [feLimbs + 2]uint64
+	n := f.n
+	var hi, lo, c, cc uint64
+	for i := 0; i < n; i++ {
+		c = 0
+		for j := 0; j < n; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+		t[n], cc = bits.Add64(t[n], c, 0)
+		t[n+1] = cc
+
+		m := t[0] * f.pInv
+		hi, lo = bits.Mul64(m, f.p[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < n; j++ {
+			hi, lo = bits.Mul64(m, f.p[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+		t[n-1], cc = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + cc
+	}
+	var d fe
+	var b uint64
+	for j := 0; j < n; j++ {
+		d[j], b = bits.Sub64(t[j], f.p[j], b)
+	}
+	_, b = bits.Sub64(t[n], 0, b)
+	var tt fe
+	copy(tt[:n], t[:n])
+	f.selectFe(z, &tt, &d, -b)
+}
+
+func (f *field) sqr(z, x *fe) {
+	f.mul(z, x, x)
+}
+
+// z = x+y mod p
+func (f *field) add(z, x, y *fe) {
+	var s, d fe
+	var c, b uint64
+	for j := 0; j < f.n; j++ {
+		s[j], c = bits.Add64(x[j], y[j], c)
+	}
+	for j := 0; j < f.n; j++ {
+		d[j], b = bits.Sub64(s[j], f.p[j], b)
+	}
+	f.selectFe(z, &s, &d, -(b &^ c))
+}
+
+// z = x-y mod p
+func (f *field) sub(z, x, y *fe) {
+	var d fe
+	var b, c uint64
+	for j := 0; j < f.n; j++ {
+		d[j], b = bits.Sub64(x[j], y[j], b)
+	}
+	mask := -b
+	for j := 0; j < f.n; j++ {
+		d[j], c = bits.Add64(d[j], f.p[j]&mask, c)
+	}
+	*z = d
+}
+
+// z = x^-1 mod p, using Fermat's little theorem.
+func (f *field) inv(z, x *fe) {
+	r := f.one
+	for i := f.pm2.BitLen() - 1; i >= 0; i-- {
+		f.sqr(&r, &r)
+		if f.pm2.Bit(i) == 1 {
+			f.mul(&r, &r, x)
+		}
+	}
+	*z = r
+}
@@ -0,0 +1,271 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestFieldArithmetic(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdGostR34102001TestParamSet(),
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		fp := c.fp
+		f := func(rawX, rawY [64]byte) bool {
+			x := bytes2big(rawX[:c.PointSize()])
+			y := bytes2big(rawY[:c.PointSize()])
+			x.Mod(x, c.P)
+			y.Mod(y, c.P)
+			fx, fy := fp.fromBig(x), fp.fromBig(y)
+			if fp.toBig(&fx).Cmp(x) != 0 {
+				return false
+			}
+			var r fe
+			var ref big.Int
+			fp.mul(&r, &fx, &fy)
+			ref.Mul(x, y)
+			ref.Mod(&ref, c.P)
+			if fp.toBig(&r).Cmp(&ref) != 0 {
+				return false
+			}
+			fp.add(&r, &fx, &fy)
+			ref.Add(x, y)
+			ref.Mod(&ref, c.P)
+			if fp.toBig(&r).Cmp(&ref) != 0 {
+				return false
+			}
+			fp.sub(&r, &fx, &fy)
+			ref.Sub(x, y)
+			ref.Mod(&ref, c.P)
+			if fp.toBig(&r).Cmp(&ref) != 0 {
+				return false
+			}
+			if x.Sign() == 0 {
+				return true
+			}
+			fp.inv(&r, &fx)
+			ref.ModInverse(x, c.P)
+			return fp.toBig(&r).Cmp(&ref) == 0
+		}
+		if err := quick.Check(f, nil); err != nil {
+			t.Error(c.Name, err)
+		}
+	}
+}
+
+func TestExpFieldEqualsBig(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		if c.fp == nil {
+			t.Fatal(c.Name, "has no field")
+		}
+		x7, y7, err := c.Exp(big.NewInt(7), c.X, c.Y)
+		if err != nil {
+			t.FailNow()
+		}
+		for i := 0; i < 4; i++ {
+			degree, err := rand.Int(rand.Reader, c.Q)
+			if err != nil {
+				t.FailNow()
+			}
+			degree.Add(degree, bigInt1)
+			for _, p := range [][2]*big.Int{{c.X, c.Y}, {x7, y7}} {
+				bits := c.Q.BitLen()
+				x, y, ok := c.fp.exp(degree, bits, p[0], p[1])
+				xRef, yRef, okRef := c.expBig(degree, bits, p[0], p[1])
+				if ok != okRef || (ok && !PointEqual(x, y, xRef, yRef)) {
+					t.Fatal(c.Name, degree)
+				}
+			}
+		}
+	}
+}
+
+func TestAddComplete(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		f := c.fp
+		x2, y2, err := c.Exp(bigInt2, c.X, c.Y)
+		if err != nil {
+			t.FailNow()
+		}
+		x3, y3, err := c.Exp(bigInt3, c.X, c.Y)
+		if err != nil {
+			t.FailNow()
+		}
+		inf := pPoint{y: f.one}
+		g := pPoint{x: f.fromBig(c.X), y: f.fromBig(c.Y), z: f.one}
+		g2 := pPoint{x: f.fromBig(x2), y: f.fromBig(y2), z: f.one}
+		gNeg := pPoint{x: g.x, z: f.one}
+		f.sub(&gNeg.y, &gNeg.y, &g.y)
+		for _, v := range []struct {
+			p1, p2 pPoint
+			x, y   *big.Int
+		}{
+			{g, inf, c.X, c.Y},
+			{inf, g, c.X, c.Y},
+			{g, g, x2, y2},
+			{g, g2, x3, y3},
+			{g, gNeg, nil, nil},
+			{inf, inf, nil, nil},
+		} {
+			var r pPoint
+			f.addComplete(&r, &v.p1, &v.p2)
+			x, y, ok := f.affineP(&r)
+			if v.x == nil {
+				if ok {
+					t.Fatal(c.Name, "point at infinity expected")
+				}
+				continue
+			}
+			if !ok || !PointEqual(x, y, v.x, v.y) {
+				t.Fatal(c.Name, "complete addition differs")
+			}
+		}
+		for _, p := range []pPoint{inf, g, g2, gNeg} {
+			var r, rRef pPoint
+			f.doubleComplete(&r, &p)
+			f.addComplete(&rRef, &p, &p)
+			x, y, ok := f.affineP(&r)
+			xRef, yRef, okRef := f.affineP(&rRef)
+			if ok != okRef || (ok && !PointEqual(x, y, xRef, yRef)) {
+				t.Fatal(c.Name, "complete doubling differs")
+			}
+		}
+	}
+}
+
+// Ladder must be correct for points outside the prime order subgroup,
+// including the small order ones, that complete formulas on curves
+// with even cofactor have exceptions for.
+func TestExpFieldOutsideSubgroup(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		tx, ty := smallOrderPoint(t, c)
+		bx, by, err := c.Add(c.X, c.Y, tx, ty)
+		if err != nil {
+			t.Fatal(err)
+		}
+		points := [][2]*big.Int{{tx, ty}, {bx, by}}
+		if tx2, ty2, err := c.Add(tx, ty, tx, ty); err == nil && tx2 != nil {
+			points = append(points, [2]*big.Int{tx2, ty2})
+		}
+		bits := c.Q.BitLen()
+		for _, degree := range []*big.Int{bigInt1, bigInt2, bigInt3, bigInt4, c.Q} {
+			for _, p := range points {
+				x, y, ok := c.fp.exp(degree, bits, p[0], p[1])
+				xRef, yRef, okRef := c.expBig(degree, bits, p[0], p[1])
+				if ok != okRef || (ok && !PointEqual(x, y, xRef, yRef)) {
+					t.Fatal(c.Name, degree)
+				}
+			}
+		}
+	}
+}
+
+func TestSignScalarEqualsBig(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		if c.fq == nil {
+			t.Fatal(c.Name, "has no scalar field")
+		}
+		cBig := *c
+		cBig.fq = nil
+		digest := make([]byte, c.PointSize())
+		for i := 0; i < 16; i++ {
+			prv, err := GenPrivateKey(c, rand.Reader)
+			if err != nil {
+				t.FailNow()
+			}
+			prvBig := &PrivateKey{&cBig, prv.Key}
+			k, err := rand.Int(rand.Reader, c.Q)
+			if err != nil {
+				t.FailNow()
+			}
+			k.Add(k, bigInt1)
+			if k.Cmp(c.Q) == 0 {
+				continue
+			}
+			rand.Read(digest)
+			if i == 0 {
+				digest = make([]byte, c.PointSize())
+			}
+			sign, err := prv.SignDigestWithK(digest, k)
+			if err != nil {
+				t.FailNow()
+			}
+			signBig, err := prvBig.SignDigestWithK(digest, k)
+			if err != nil {
+				t.FailNow()
+			}
+			if bytes.Compare(sign, signBig) != 0 {
+				t.Fatal(c.Name, "with k differs")
+			}
+			sign, err = prv.signDigest(digest, rand.Reader, true)
+			if err != nil {
+				t.FailNow()
+			}
+			pub, err := prvBig.PublicKey()
+			if err != nil {
+				t.FailNow()
+			}
+			valid, err := pub.VerifyDigest(digest, sign)
+			if err != nil || !valid {
+				t.Fatal(c.Name, "blinded is invalid")
+			}
+		}
+	}
+}
+
+func benchmarkExp(b *testing.B, c *Curve, viaField bool) {
+	degree, err := rand.Int(rand.Reader, c.Q)
+	if err != nil {
+		b.FailNow()
+	}
+	bits := c.Q.BitLen()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if viaField {
+			c.fp.exp(degree, bits, c.X, c.Y)
+		} else {
+			c.expBig(degree, bits, c.X, c.Y)
+		}
+	}
+}
+
+func BenchmarkExpField256(b *testing.B) {
+	benchmarkExp(b, CurveIdtc26gost341012256paramSetB(), true)
+}
+
+func BenchmarkExpBig256(b *testing.B) {
+	benchmarkExp(b, CurveIdtc26gost341012256paramSetB(), false)
+}
+
+func BenchmarkExpField512(b *testing.B) {
+	benchmarkExp(b, CurveIdtc26gost341012512paramSetA(), true)
+}
+
+func BenchmarkExpBig512(b *testing.B) {
+	benchmarkExp(b, CurveIdtc26gost341012512paramSetA(), false)
+}
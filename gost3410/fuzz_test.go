@@ -0,0 +1,120 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build go1.18
+// +build go1.18
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func FuzzUnmarshalSignatureDER(f *testing.F) {
+	prv, err := GenPrivateKey(CurveIdtc26gost341012256paramSetA(), rand.Reader)
+	if err != nil {
+		f.FailNow()
+	}
+	sig, err := prv.SignDigest(make([]byte, 32), rand.Reader)
+	if err != nil {
+		f.FailNow()
+	}
+	der, err := MarshalSignatureDER(sig)
+	if err != nil {
+		f.FailNow()
+	}
+	f.Add(der)
+	f.Add([]byte{0x30, 0x00})
+	f.Fuzz(func(t *testing.T, der []byte) {
+		sig, err := UnmarshalSignatureDER(der)
+		if err != nil {
+			return
+		}
+		again, err := MarshalSignatureDER(sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigAgain, err := UnmarshalSignatureDER(again)
+		if err != nil || bytes.Compare(sig, sigAgain) != 0 {
+			t.Fatal("re-marshaled signature differs")
+		}
+	})
+}
+
+func FuzzVerifyDigest(f *testing.F) {
+	c := CurveIdtc26gost341012256paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		f.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		f.FailNow()
+	}
+	digest := make([]byte, 32)
+	sig, err := prv.SignDigest(digest, rand.Reader)
+	if err != nil {
+		f.FailNow()
+	}
+	f.Add(digest, sig)
+	f.Add([]byte{}, []byte{})
+	f.Add(digest, sig[:63])
+	f.Fuzz(func(t *testing.T, digest, sig []byte) {
+		valid, err := pub.VerifyDigest(digest, sig)
+		if len(sig) != 2*c.PointSize() && (err == nil || valid) {
+			t.Fatal("malformed signature length is accepted")
+		}
+		if err != nil && valid {
+			t.Fatal("valid with error")
+		}
+	})
+}
+
+func FuzzParsePKIXPublicKey(f *testing.F) {
+	for _, c := range []*Curve{
+		CurveIdGostR34102001CryptoProAParamSet(),
+		CurveIdtc26gost341012512paramSetA(),
+	} {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			f.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			f.FailNow()
+		}
+		der, err := MarshalPKIXPublicKey(pub)
+		if err != nil {
+			f.FailNow()
+		}
+		f.Add(der)
+	}
+	f.Fuzz(func(t *testing.T, der []byte) {
+		pub, err := ParsePKIXPublicKey(der)
+		if err != nil {
+			return
+		}
+		again, err := MarshalPKIXPublicKey(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubAgain, err := ParsePKIXPublicKey(again)
+		if err != nil || !pubAgain.Equal(pub) {
+			t.Fatal("re-marshaled public key differs")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+)
+
+// Suite identifier prepended to every hashed block in HashToPoint.
+const HashToPointSuite = "GOGOST-HTP-STREEBOG512-TAI-v1"
+
+// Map data to the point of Q order subgroup with try-and-increment
+// method. For every 32-bit big-endian counter value ctr (starting from
+// zero) PointSize/32 blocks are computed:
+//
+//	H_j = Streebog-512(HashToPointSuite || ctr || byte(j) || data)
+//
+// Their concatenation is interpreted as big-endian integer and is
+// reduced modulo P to x candidate, so the bias is negligible. If
+// x^3+ax+b is a square, then y is its root with the lowest bit equal to
+// the lowest bit of H_0's last byte, otherwise next counter is tried.
+// Point is multiplied by the cofactor if it is greater than 1; point at
+// infinity is skipped too. Each try succeeds with probability near to
+// 1/2, so error is returned only after 256 failed ones. Resulting point's
+// discrete logarithm is unknown. Pay attention that the number of tries
+// depends on data, so the mapping is not constant time and must not be
+// applied to secrets where the timing is observable.
+func (c *Curve) HashToPoint(data []byte) (x, y *big.Int, err error) {
+	blocks := c.PointSize() / 32
+	h := gost34112012512.New()
+	hdr := make([]byte, len(HashToPointSuite)+4+1)
+	copy(hdr, HashToPointSuite)
+	buf := make([]byte, 0, blocks*gost34112012512.Size)
+	cofactor := c.cofactor()
+	for ctr := uint32(0); ctr < 256; ctr++ {
+		binary.BigEndian.PutUint32(hdr[len(HashToPointSuite):], ctr)
+		buf = buf[:0]
+		for j := 0; j < blocks; j++ {
+			hdr[len(hdr)-1] = byte(j)
+			h.Reset()
+			h.Write(hdr)
+			h.Write(data)
+			buf = h.Sum(buf)
+		}
+		x = bytes2big(buf)
+		x.Mod(x, c.P)
+		if y = c.recoverY(x, buf[gost34112012512.Size-1]&1 == 1); y == nil {
+			continue
+		}
+		if cofactor.Cmp(bigInt1) == 0 {
+			return x, y, nil
+		}
+		if x, y, err = c.Exp(cofactor, x, y); err != nil {
+			continue
+		}
+		return x, y, nil
+	}
+	return nil, nil, errors.New("gogost/gost3410: hash to point failed")
+}
@@ -0,0 +1,87 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestHashToPointVectors(t *testing.T) {
+	// Self-generated with the mapping described in HashToPoint
+	for _, v := range []struct {
+		c    *Curve
+		x, y string
+	}{
+		{
+			CurveIdtc26gost341012256paramSetA(),
+			"179c1cd2822b06d92c242a5efafa701fb7d0975d9e6784663ba97bd8cf531a7a",
+			"dcfafbdbc6d4a9e827af5d5d4ed735d128f56cea8abb1b7ee9a6bd6bd2f6cfb",
+		},
+		{
+			CurveIdGostR34102001CryptoProAParamSet(),
+			"f1532a764744decf852c0576d506e6e6268836c4bf86ca391ce8fff4e5fae466",
+			"3b5bea6d2fa978e176037b2b841144026bdb1a446a706de5267eb00aa2a801e",
+		},
+		{
+			CurveIdtc26gost341012512paramSetC(),
+			"397424c2f8cb2c54ed1a6f68bdf6a338299146c949914a312373e61dafba67f4" +
+				"ac2ca11621a00887f15088fa9612aff52a4d59e0fa830a6ac7013c49e9d6f76b",
+			"63e338990dc6b1f2a303ba63a5168a14afc9ca024d9d8cc75a2944e83e3667a9" +
+				"dfd5322fa1a96226882f0015e259a6d62fc03747be34898b8ded9f33dd1d7433",
+		},
+	} {
+		x, y, err := v.c.HashToPoint([]byte("gogost"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		xExpected, _ := big.NewInt(0).SetString(v.x, 16)
+		yExpected, _ := big.NewInt(0).SetString(v.y, 16)
+		if x.Cmp(xExpected) != 0 || y.Cmp(yExpected) != 0 {
+			t.Fatal("vector mismatch", v.c.Name)
+		}
+		if !v.c.contains(x, y) {
+			t.Fatal("point is not on the curve")
+		}
+		if _, _, err = v.c.Exp(v.c.Q, x, y); err == nil {
+			t.Fatal("point is not in the subgroup")
+		}
+	}
+}
+
+func TestHashToPointUniformity(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	seen := make(map[string]struct{})
+	var odd int
+	const n = 200
+	for i := 0; i < n; i++ {
+		x, y, err := c.HashToPoint([]byte{byte(i), byte(i >> 8)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !c.inSubgroup(x, y) {
+			t.Fatal("point is not in the subgroup")
+		}
+		seen[x.Text(16)] = struct{}{}
+		odd += int(y.Bit(0))
+	}
+	if len(seen) != n {
+		t.Fatal("duplicate points")
+	}
+	if odd < n/4 || odd > 3*n/4 {
+		t.Fatal("skewed y parity", odd)
+	}
+}
@@ -0,0 +1,284 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"math/big"
+)
+
+// Point in Jacobian coordinates (X/Z^2, Y/Z^3) over Montgomery form
+// field elements. Z equal to zero is the point at infinity.
+type jPoint struct {
+	x, y, z fe
+}
+
+// Doubling with arbitrary A coefficient, "dbl-2007-bl" formulas.
+func (f *field) double(r, p *jPoint) {
+	if f.isZero(&p.z) || f.isZero(&p.y) {
+		r.z = fe{}
+		return
+	}
+	var xx, yy, yyyy, zz, s, m, t fe
+	f.sqr(&xx, &p.x)
+	f.sqr(&yy, &p.y)
+	f.sqr(&yyyy, &yy)
+	f.sqr(&zz, &p.z)
+	f.add(&s, &p.x, &yy)
+	f.sqr(&s, &s)
+	f.sub(&s, &s, &xx)
+	f.sub(&s, &s, &yyyy)
+	f.add(&s, &s, &s)
+	f.sqr(&m, &zz)
+	f.mul(&m, &m, &f.a)
+	f.add(&m, &m, &xx)
+	f.add(&m, &m, &xx)
+	f.add(&m, &m, &xx)
+	f.add(&r.z, &p.y, &p.z)
+	f.sqr(&r.z, &r.z)
+	f.sub(&r.z, &r.z, &yy)
+	f.sub(&r.z, &r.z, &zz)
+	f.sqr(&t, &m)
+	f.sub(&t, &t, &s)
+	f.sub(&t, &t, &s)
+	r.x = t
+	f.sub(&s, &s, &t)
+	f.mul(&r.y, &m, &s)
+	f.add(&yyyy, &yyyy, &yyyy)
+	f.add(&yyyy, &yyyy, &yyyy)
+	f.add(&yyyy, &yyyy, &yyyy)
+	f.sub(&r.y, &r.y, &yyyy)
+}
+
+// Addition, "add-2007-bl" formulas. Equal points are doubled and
+// negation of each other gives point at infinity.
+func (f *field) addPoints(r, p1, p2 *jPoint) {
+	if f.isZero(&p1.z) {
+		*r = *p2
+		return
+	}
+	if f.isZero(&p2.z) {
+		*r = *p1
+		return
+	}
+	var z1z1, z2z2, u1, u2, s1, s2, h, i, j, rr, v fe
+	f.sqr(&z1z1, &p1.z)
+	f.sqr(&z2z2, &p2.z)
+	f.mul(&u1, &p1.x, &z2z2)
+	f.mul(&u2, &p2.x, &z1z1)
+	f.mul(&s1, &p1.y, &p2.z)
+	f.mul(&s1, &s1, &z2z2)
+	f.mul(&s2, &p2.y, &p1.z)
+	f.mul(&s2, &s2, &z1z1)
+	f.sub(&h, &u2, &u1)
+	f.sub(&rr, &s2, &s1)
+	if f.isZero(&h) {
+		if f.isZero(&rr) {
+			f.double(r, p1)
+		} else {
+			r.z = fe{}
+		}
+		return
+	}
+	f.add(&rr, &rr, &rr)
+	f.add(&i, &h, &h)
+	f.sqr(&i, &i)
+	f.mul(&j, &h, &i)
+	f.mul(&v, &u1, &i)
+	f.add(&r.z, &p1.z, &p2.z)
+	f.sqr(&r.z, &r.z)
+	f.sub(&r.z, &r.z, &z1z1)
+	f.sub(&r.z, &r.z, &z2z2)
+	f.mul(&r.z, &r.z, &h)
+	f.sqr(&r.x, &rr)
+	f.sub(&r.x, &r.x, &j)
+	f.sub(&r.x, &r.x, &v)
+	f.sub(&r.x, &r.x, &v)
+	f.sub(&v, &v, &r.x)
+	f.mul(&s1, &s1, &j)
+	f.add(&s1, &s1, &s1)
+	f.mul(&r.y, &rr, &v)
+	f.sub(&r.y, &r.y, &s1)
+}
+
+// Point in projective coordinates (X/Z, Y/Z) over Montgomery form
+// field elements. (0, 1, 0) is the point at infinity.
+type pPoint struct {
+	x, y, z fe
+}
+
+// Complete addition for arbitrary A coefficient, algorithm 1 of
+// Renes, Costello and Batina "Complete addition formulas for prime
+// order elliptic curves". The same operations are made for any points
+// of odd order, including the point at infinity and equal points, so
+// there are no branches. r may be the same as p1 or p2.
+func (f *field) addComplete(r, p1, p2 *pPoint) {
+	var t0, t1, t2, t3, t4, t5, x3, y3, z3 fe
+	f.mul(&t0, &p1.x, &p2.x)
+	f.mul(&t1, &p1.y, &p2.y)
+	f.mul(&t2, &p1.z, &p2.z)
+	f.add(&t3, &p1.x, &p1.y)
+	f.add(&t4, &p2.x, &p2.y)
+	f.mul(&t3, &t3, &t4)
+	f.add(&t4, &t0, &t1)
+	f.sub(&t3, &t3, &t4)
+	f.add(&t4, &p1.x, &p1.z)
+	f.add(&t5, &p2.x, &p2.z)
+	f.mul(&t4, &t4, &t5)
+	f.add(&t5, &t0, &t2)
+	f.sub(&t4, &t4, &t5)
+	f.add(&t5, &p1.y, &p1.z)
+	f.add(&x3, &p2.y, &p2.z)
+	f.mul(&t5, &t5, &x3)
+	f.add(&x3, &t1, &t2)
+	f.sub(&t5, &t5, &x3)
+	f.mul(&z3, &f.a, &t4)
+	f.mul(&x3, &f.b3, &t2)
+	f.add(&z3, &x3, &z3)
+	f.sub(&x3, &t1, &z3)
+	f.add(&z3, &t1, &z3)
+	f.mul(&y3, &x3, &z3)
+	f.add(&t1, &t0, &t0)
+	f.add(&t1, &t1, &t0)
+	f.mul(&t2, &f.a, &t2)
+	f.mul(&t4, &f.b3, &t4)
+	f.add(&t1, &t1, &t2)
+	f.sub(&t2, &t0, &t2)
+	f.mul(&t2, &f.a, &t2)
+	f.add(&t4, &t4, &t2)
+	f.mul(&t0, &t1, &t4)
+	f.add(&y3, &y3, &t0)
+	f.mul(&t0, &t5, &t4)
+	f.mul(&x3, &t3, &x3)
+	f.sub(&x3, &x3, &t0)
+	f.mul(&t0, &t3, &t1)
+	f.mul(&z3, &t5, &z3)
+	f.add(&z3, &z3, &t0)
+	r.x, r.y, r.z = x3, y3, z3
+}
+
+// Complete doubling for arbitrary A coefficient, algorithm 3 of the
+// same paper: cheaper addComplete(r, p, p). r may be the same as p.
+func (f *field) doubleComplete(r, p *pPoint) {
+	var t0, t1, t2, t3, x3, y3, z3 fe
+	f.sqr(&t0, &p.x)
+	f.sqr(&t1, &p.y)
+	f.sqr(&t2, &p.z)
+	f.mul(&t3, &p.x, &p.y)
+	f.add(&t3, &t3, &t3)
+	f.mul(&z3, &p.x, &p.z)
+	f.add(&z3, &z3, &z3)
+	f.mul(&x3, &f.a, &z3)
+	f.mul(&y3, &f.b3, &t2)
+	f.add(&y3, &x3, &y3)
+	f.sub(&x3, &t1, &y3)
+	f.add(&y3, &t1, &y3)
+	f.mul(&y3, &x3, &y3)
+	f.mul(&x3, &t3, &x3)
+	f.mul(&z3, &f.b3, &z3)
+	f.mul(&t2, &f.a, &t2)
+	f.sub(&t3, &t0, &t2)
+	f.mul(&t3, &f.a, &t3)
+	f.add(&t3, &t3, &z3)
+	f.add(&z3, &t0, &t0)
+	f.add(&t0, &z3, &t0)
+	f.add(&t0, &t0, &t2)
+	f.mul(&t0, &t0, &t3)
+	f.add(&y3, &y3, &t0)
+	f.mul(&t2, &p.y, &p.z)
+	f.add(&t2, &t2, &t2)
+	f.mul(&t0, &t2, &t3)
+	f.sub(&x3, &x3, &t0)
+	f.mul(&z3, &t2, &t1)
+	f.add(&z3, &z3, &z3)
+	f.add(&z3, &z3, &z3)
+	r.x, r.y, r.z = x3, y3, z3
+}
+
+// Constant time r = p if mask is all ones, unchanged if it is zero.
+func (f *field) selectPoint(r, p *pPoint, mask uint64) {
+	f.selectFe(&r.x, &p.x, &r.x, mask)
+	f.selectFe(&r.y, &p.y, &r.y, mask)
+	f.selectFe(&r.z, &p.z, &r.z, mask)
+}
+
+// Convert projective point to affine coordinates. ok is false for
+// point at infinity.
+func (f *field) affineP(p *pPoint) (x, y *big.Int, ok bool) {
+	if f.isZero(&p.z) {
+		return nil, nil, false
+	}
+	var zInv, ax, ay fe
+	f.inv(&zInv, &p.z)
+	f.mul(&ax, &p.x, &zInv)
+	f.mul(&ay, &p.y, &zInv)
+	return f.toBig(&ax), f.toBig(&ay), true
+}
+
+// Convert point to affine coordinates. ok is false for point at
+// infinity.
+func (f *field) affine(p *jPoint) (x, y *big.Int, ok bool) {
+	if f.isZero(&p.z) {
+		return nil, nil, false
+	}
+	var zInv, zInv2 fe
+	f.inv(&zInv, &p.z)
+	f.sqr(&zInv2, &zInv)
+	var ax, ay fe
+	f.mul(&ax, &p.x, &zInv2)
+	f.mul(&zInv2, &zInv2, &zInv)
+	f.mul(&ay, &p.y, &zInv2)
+	return f.toBig(&ax), f.toBig(&ay), true
+}
+
+// Constant time swap of p and q if mask is all ones, nothing is done
+// if it is zero.
+func (f *field) swapPoints(p, q *pPoint, mask uint64) {
+	for _, c := range [...][2]*fe{{&p.x, &q.x}, {&p.y, &q.y}, {&p.z, &q.z}} {
+		for i := 0; i < f.n; i++ {
+			t := (c[0][i] ^ c[1][i]) & mask
+			c[0][i] ^= t
+			c[1][i] ^= t
+		}
+	}
+}
+
+// Montgomery ladder over projective coordinates with complete addition
+// and doubling formulas. Points are swapped with masks
+// depending on the degree bits, so there are no branches on them.
+// Difference of ladder's points is always the multiplied point, so
+// formulas exceptional cases are avoided if it is not of order two.
+// Point of order two, with zero Y, is public and is handled separately.
+func (f *field) exp(degree *big.Int, bits int, xS, yS *big.Int) (x, y *big.Int, ok bool) {
+	if yS.Sign() == 0 {
+		if degree.Bit(0) == 0 {
+			return nil, nil, false
+		}
+		return big.NewInt(0).Set(xS), big.NewInt(0), true
+	}
+	r0 := pPoint{y: f.one}
+	r1 := pPoint{x: f.fromBig(xS), y: f.fromBig(yS), z: f.one}
+	var prev uint64
+	for i := bits - 1; i >= 0; i-- {
+		bit := uint64(degree.Bit(i))
+		f.swapPoints(&r0, &r1, -(bit ^ prev))
+		prev = bit
+		f.addComplete(&r1, &r1, &r0)
+		f.doubleComplete(&r0, &r0)
+	}
+	f.swapPoints(&r0, &r1, -prev)
+	r1 = pPoint{}
+	return f.affineP(&r0)
+}
@@ -0,0 +1,76 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+type publicKeyJSON struct {
+	Curve      string `json:"curve"`
+	DigestSize int    `json:"digestSize"`
+	X          string `json:"x"`
+	Y          string `json:"y"`
+}
+
+// Marshal public key to JSON object with curve's name (as CurveByName
+// knows it), digest size in bytes and big-endian hexadecimal X and Y
+// coordinates. Curves absent in registry can not be marshaled.
+func (pub *PublicKey) MarshalJSON() ([]byte, error) {
+	if c, ok := CurveByName(pub.C.Name); !ok || !c.Equal(pub.C) {
+		return nil, errors.New("gogost/gost3410: curve is not predefined")
+	}
+	pointSize := pub.C.PointSize()
+	return json.Marshal(publicKeyJSON{
+		Curve:      pub.C.Name,
+		DigestSize: DigestSizeForCurve(pub.C),
+		X:          hex.EncodeToString(pad(pub.X.Bytes(), pointSize)),
+		Y:          hex.EncodeToString(pad(pub.Y.Bytes(), pointSize)),
+	})
+}
+
+// Unmarshal public key from JSON object made by MarshalJSON. Point is
+// validated as NewPublicKey does: it must be on the curve and in the
+// prime order subgroup.
+func (pub *PublicKey) UnmarshalJSON(data []byte) error {
+	var v publicKeyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	c, ok := CurveByName(v.Curve)
+	if !ok {
+		return errorf(ErrUnknownCurve, "gogost/gost3410: unknown curve %q", v.Curve)
+	}
+	if v.DigestSize != DigestSizeForCurve(c) {
+		return ErrDigestSizeMismatch
+	}
+	x, err := hex.DecodeString(v.X)
+	if err != nil {
+		return err
+	}
+	y, err := hex.DecodeString(v.Y)
+	if err != nil {
+		return err
+	}
+	got, err := NewPublicKeyBigEndian(c, x, y)
+	if err != nil {
+		return err
+	}
+	*pub = *got
+	return nil
+}
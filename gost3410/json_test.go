@@ -0,0 +1,126 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+const publicKeyJSONGolden = `{"curve":"id-tc26-gost-3410-12-256-paramSetA","digestSize":32,` +
+	`"x":"91e38443a5e82c0d880923425712b2bb658b9196932e02c78b2582fe742daa28",` +
+	`"y":"32879423ab1a0375895786c4bb46e9565fde0b5344766740af268adb32322e5c"}`
+
+func TestPublicKeyJSONGolden(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	data, err := json.Marshal(&PublicKey{c, c.X, c.Y})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != publicKeyJSONGolden {
+		t.Fatal(string(data))
+	}
+	var pub PublicKey
+	if err = json.Unmarshal([]byte(publicKeyJSONGolden), &pub); err != nil {
+		t.Fatal(err)
+	}
+	if !pub.C.Equal(c) || pub.X.Cmp(c.X) != 0 || pub.Y.Cmp(c.Y) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestPublicKeyJSONRoundTrip(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdGostR34102001CryptoProAParamSet(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		data, err := json.Marshal(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got PublicKey
+		if err = json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(pub) {
+			t.FailNow()
+		}
+	}
+}
+
+func TestPublicKeyJSONInvalid(t *testing.T) {
+	var pub PublicKey
+	for _, data := range []string{
+		strings.Replace(publicKeyJSONGolden, "paramSetA", "paramSetZ", 1),
+		strings.Replace(publicKeyJSONGolden, `"digestSize":32`, `"digestSize":64`, 1),
+		strings.Replace(publicKeyJSONGolden, `"x":"91`, `"x":"92`, 1),
+		strings.Replace(publicKeyJSONGolden, `"x":"91`, `"x":"`, 1),
+	} {
+		if err := json.Unmarshal([]byte(data), &pub); err == nil {
+			t.Fatal(data)
+		}
+	}
+	c := CurveIdtc26gost341012256paramSetA()
+	c.Name = "unknown"
+	if _, err := json.Marshal(&PublicKey{c, c.X, c.Y}); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestPublicKeyJSONSubgroup(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	tx, ty := smallOrderPoint(t, c)
+	bx, by, err := c.Add(pub.X, pub.Y, tx, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range [][2]*big.Int{{tx, ty}, {bx, by}} {
+		data, err := json.Marshal(publicKeyJSON{
+			Curve:      c.Name,
+			DigestSize: 32,
+			X:          hex.EncodeToString(pad(p[0].Bytes(), 32)),
+			Y:          hex.EncodeToString(pad(p[1].Bytes(), 32)),
+		})
+		if err != nil {
+			t.FailNow()
+		}
+		var got PublicKey
+		if err = json.Unmarshal(data, &got); !errors.Is(err, ErrPointNotInSubgroup) {
+			t.Fatal(err)
+		}
+	}
+}
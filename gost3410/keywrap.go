@@ -0,0 +1,89 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"math/big"
+
+	"go.cypherpunks.ru/gogost/v5/gost28147"
+)
+
+func keyTransportUKM(ukm []byte) (*big.Int, error) {
+	if len(ukm) != 8 {
+		return nil, ErrInvalidUKMLength
+	}
+	return NewUKM(ukm), nil
+}
+
+// Wrap 32-byte CEK for pub's owner with GOST R 34.10-2001 keys: KEK is
+// derived from prv, pub and 8-byte UKM with VKO GOST R 34.10-2001
+// (KEK2001), as RFC 4490 does, then CEK is wrapped with CryptoPro key
+// wrap (RFC 4357 6.3) with gost28147.SboxDefault. Result is
+// CEK_ENC || CEK_MAC, as Gost28147-89-EncryptedKey in CMS holds. Only
+// 256-bit curves are allowed.
+func WrapKey2001(prv *PrivateKey, pub *PublicKey, ukm, cek []byte) ([]byte, error) {
+	u, err := keyTransportUKM(ukm)
+	if err != nil {
+		return nil, err
+	}
+	kek, err := prv.KEK2001(pub, u)
+	if err != nil {
+		return nil, err
+	}
+	return gost28147.KeyWrapCryptoPro(kek, ukm, cek)
+}
+
+// Unwrap CEK wrapped with WrapKey2001. pub is the other party's public
+// key, for example the ephemeral one from CMS KeyTransport.
+func UnwrapKey2001(prv *PrivateKey, pub *PublicKey, ukm, wrappedKey []byte) ([]byte, error) {
+	u, err := keyTransportUKM(ukm)
+	if err != nil {
+		return nil, err
+	}
+	kek, err := prv.KEK2001(pub, u)
+	if err != nil {
+		return nil, err
+	}
+	return gost28147.KeyUnwrapCryptoPro(kek, ukm, wrappedKey)
+}
+
+// Wrap 32-byte CEK for pub's owner with GOST R 34.10-2012 keys of any
+// size: it is WrapKey2001 with KEK derived with VKO GOST R 34.10-2012
+// 256-bit (KEK2012256) instead.
+func WrapKey2012(prv *PrivateKey, pub *PublicKey, ukm, cek []byte) ([]byte, error) {
+	u, err := keyTransportUKM(ukm)
+	if err != nil {
+		return nil, err
+	}
+	kek, err := prv.KEK2012256(pub, u)
+	if err != nil {
+		return nil, err
+	}
+	return gost28147.KeyWrapCryptoPro(kek, ukm, cek)
+}
+
+// Unwrap CEK wrapped with WrapKey2012.
+func UnwrapKey2012(prv *PrivateKey, pub *PublicKey, ukm, wrappedKey []byte) ([]byte, error) {
+	u, err := keyTransportUKM(ukm)
+	if err != nil {
+		return nil, err
+	}
+	kek, err := prv.KEK2012256(pub, u)
+	if err != nil {
+		return nil, err
+	}
+	return gost28147.KeyUnwrapCryptoPro(kek, ukm, wrappedKey)
+}
@@ -0,0 +1,191 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+)
+
+type keyWrapFuncs struct {
+	wrap   func(prv *PrivateKey, pub *PublicKey, ukm, cek []byte) ([]byte, error)
+	unwrap func(prv *PrivateKey, pub *PublicKey, ukm, wrappedKey []byte) ([]byte, error)
+}
+
+var (
+	keyWrap2001 = keyWrapFuncs{WrapKey2001, UnwrapKey2001}
+	keyWrap2012 = keyWrapFuncs{WrapKey2012, UnwrapKey2012}
+)
+
+func TestWrapKey(t *testing.T) {
+	for _, v := range []struct {
+		c *Curve
+		f keyWrapFuncs
+	}{
+		{CurveIdGostR34102001CryptoProXchAParamSet(), keyWrap2001},
+		{CurveIdtc26gost341012256paramSetA(), keyWrap2012},
+		{CurveIdtc26gost341012512paramSetA(), keyWrap2012},
+	} {
+		prvAlice, err := GenPrivateKey(v.c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pubAlice, err := prvAlice.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		prvBob, err := GenPrivateKey(v.c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pubBob, err := prvBob.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		ukm := make([]byte, 8)
+		cek := make([]byte, 32)
+		rand.Read(ukm)
+		rand.Read(cek)
+		wrapped, err := v.f.wrap(prvAlice, pubBob, ukm, cek)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(wrapped) != 36 {
+			t.FailNow()
+		}
+		got, err := v.f.unwrap(prvBob, pubAlice, ukm, wrapped)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Compare(got, cek) != 0 {
+			t.FailNow()
+		}
+		if _, err = v.f.unwrap(prvBob, pubBob, ukm, wrapped); err == nil {
+			t.FailNow()
+		}
+		ukm[0] ^= 0x01
+		if _, err = v.f.unwrap(prvBob, pubAlice, ukm, wrapped); err == nil {
+			t.FailNow()
+		}
+		if _, err = v.f.wrap(prvAlice, pubBob, ukm[:7], cek); err == nil {
+			t.FailNow()
+		}
+	}
+}
+
+func TestWrapKeyVKOMismatch(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	ukm := make([]byte, 8)
+	cek := make([]byte, 32)
+	rand.Read(ukm)
+	rand.Read(cek)
+	wrapped, err := WrapKey2001(prv, pub, ukm, cek)
+	if err != nil {
+		t.FailNow()
+	}
+	if _, err = UnwrapKey2012(prv, pub, ukm, wrapped); err == nil {
+		t.FailNow()
+	}
+	c = CurveIdtc26gost341012512paramSetA()
+	prv, err = GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err = prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	if _, err = WrapKey2001(prv, pub, ukm, cek); err == nil {
+		t.FailNow()
+	}
+}
+
+// Vectors are produced with Nettle 3.9 gostdsa_vko, its GOST R 34.11-94
+// (CryptoPro) or Streebog-256 hash for KEK, and GnuTLS 3.7.9 CryptoPro
+// key wrap with CryptoPro-A parameters, as GnuTLS key transport does.
+func TestWrapKeyVectors(t *testing.T) {
+	ukm, _ := hex.DecodeString("a0a1a2a3a4a5a6a7")
+	cek, _ := hex.DecodeString("303132333435363738393a3b3c3d3e3f404142434445464748494a4b4c4d4e4f")
+	for _, v := range []struct {
+		c       *Curve
+		f       keyWrapFuncs
+		prvRaw1 string
+		prvRaw2 string
+		wrapped string
+	}{
+		{
+			CurveIdGostR34102001CryptoProXchAParamSet(),
+			keyWrap2001,
+			"7a929ade789bb9be10ed359dd39a72c11b60961f49397eee1d19ce9891ec3b28",
+			"4b3d1e2a6c8f0e1d2c3b4a5968778695a4b3c2d1e0f1021324354657687980a1",
+			"52360c15bb8e5c826f9cb8c0dd3acbdf203ee1b0cc323dd934c24dc0830760b565d4f6ff",
+		},
+		{
+			CurveIdtc26gost341012256paramSetB(),
+			keyWrap2012,
+			"7a929ade789bb9be10ed359dd39a72c11b60961f49397eee1d19ce9891ec3b28",
+			"4b3d1e2a6c8f0e1d2c3b4a5968778695a4b3c2d1e0f1021324354657687980a1",
+			"9de8b9c7156d6496da9c1b9c9bb989f53e0dc2f39675e0202af8f88639f6bc4006141679",
+		},
+		{
+			CurveIdtc26gost341012512paramSetA(),
+			keyWrap2012,
+			"0ba6048aadae241ba40936d47756d7c93091a0e8514669700ee7508e508b1020" +
+				"72e8123b2200a0563322dad2827e2714a2636b7bfd18aadfc62967821fa18dd4",
+			"1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f00f" +
+				"1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f00f",
+			"7e5823e689e90bfa68d488eb64f44a86ecf07703d65b0b378a7d49f6eb8778315a43bb7b",
+		},
+	} {
+		prvRaw1, _ := hex.DecodeString(v.prvRaw1)
+		prvRaw2, _ := hex.DecodeString(v.prvRaw2)
+		wrapped, _ := hex.DecodeString(v.wrapped)
+		prv1, err := NewPrivateKeyBigEndian(v.c, prvRaw1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prv2, err := NewPrivateKeyBigEndian(v.c, prvRaw2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub1, _ := prv1.PublicKey()
+		pub2, _ := prv2.PublicKey()
+		got, err := v.f.wrap(prv1, pub2, ukm, cek)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Compare(got, wrapped) != 0 {
+			t.Fatal("wrap mismatch", v.c.Name)
+		}
+		got, err = v.f.unwrap(prv2, pub1, ukm, wrapped)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Compare(got, cek) != 0 {
+			t.Fatal("unwrap mismatch", v.c.Name)
+		}
+	}
+}
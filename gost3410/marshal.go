@@ -0,0 +1,74 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import "math/big"
+
+// Marshal the point to uncompressed 0x04||X||Y form of SEC 1 2.3.3, as
+// elliptic.Marshal does. Coordinates are big-endian and padded to
+// PointSize. Pay attention that GOST's native public key representation
+// (PublicKey.Raw, NewPublicKey, RFC 4491) is different: it is X||Y,
+// each little-endian, without any prefix.
+func (c *Curve) Marshal(x, y *big.Int) []byte {
+	pointSize := c.PointSize()
+	data := make([]byte, 1, 1+2*pointSize)
+	data[0] = 0x04
+	data = append(data, pad(x.Bytes(), pointSize)...)
+	return append(data, pad(y.Bytes(), pointSize)...)
+}
+
+// Marshal the point to compressed 0x02/0x03||X form of SEC 1 2.3.3, as
+// elliptic.MarshalCompressed does: prefix is 0x03 for odd Y, 0x02
+// otherwise. X is big-endian and padded to PointSize.
+func (c *Curve) MarshalCompressed(x, y *big.Int) []byte {
+	data := make([]byte, 1, 1+c.PointSize())
+	data[0] = byte(0x02 | y.Bit(0))
+	return append(data, pad(x.Bytes(), c.PointSize())...)
+}
+
+// Unmarshal the point made by Marshal. Nil coordinates are returned if
+// data is malformed or the point is not on the curve.
+func (c *Curve) Unmarshal(data []byte) (x, y *big.Int) {
+	pointSize := c.PointSize()
+	if len(data) != 1+2*pointSize || data[0] != 0x04 {
+		return nil, nil
+	}
+	x = bytes2big(data[1 : 1+pointSize])
+	y = bytes2big(data[1+pointSize:])
+	if !c.contains(x, y) {
+		return nil, nil
+	}
+	return x, y
+}
+
+// Unmarshal the point made by MarshalCompressed. Y is recovered from the
+// curve's equation. Nil coordinates are returned if data is malformed
+// or there is no point with such X.
+func (c *Curve) UnmarshalCompressed(data []byte) (x, y *big.Int) {
+	pointSize := c.PointSize()
+	if len(data) != 1+pointSize || (data[0] != 0x02 && data[0] != 0x03) {
+		return nil, nil
+	}
+	x = bytes2big(data[1:])
+	if x.Cmp(c.P) >= 0 {
+		return nil, nil
+	}
+	y = c.recoverY(x, data[0] == 0x03)
+	if y == nil || y.Bit(0) != uint(data[0]&1) {
+		return nil, nil
+	}
+	return x, y
+}
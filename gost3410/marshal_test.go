@@ -0,0 +1,98 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdGostR34102001CryptoProAParamSet(),
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetB(),
+	} {
+		pointSize := c.PointSize()
+		for i := 0; i < 8; i++ {
+			prv, err := GenPrivateKey(c, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pub, err := prv.PublicKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data := c.Marshal(pub.X, pub.Y)
+			if len(data) != 1+2*pointSize || data[0] != 0x04 {
+				t.Fatal("invalid uncompressed form")
+			}
+			x, y := c.Unmarshal(data)
+			if x == nil || x.Cmp(pub.X) != 0 || y.Cmp(pub.Y) != 0 {
+				t.Fatal("uncompressed round trip failed")
+			}
+			raw := pub.Raw()
+			reverse(raw)
+			if bytes.Compare(data[1:], append(raw[pointSize:], raw[:pointSize]...)) != 0 {
+				t.Fatal("differs from reversed native form")
+			}
+			data = c.MarshalCompressed(pub.X, pub.Y)
+			if len(data) != 1+pointSize || data[0] != byte(0x02+pub.Y.Bit(0)) {
+				t.Fatal("invalid compressed form")
+			}
+			x, y = c.UnmarshalCompressed(data)
+			if x == nil || x.Cmp(pub.X) != 0 || y.Cmp(pub.Y) != 0 {
+				t.Fatal("compressed round trip failed")
+			}
+			data[0] ^= 1
+			if _, y = c.UnmarshalCompressed(data); y.Cmp(pub.Y) == 0 {
+				t.Fatal("prefix ignored")
+			}
+		}
+	}
+}
+
+func TestUnmarshalMalformed(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	data := c.Marshal(c.X, c.Y)
+	if x, _ := c.Unmarshal(data); x == nil {
+		t.FailNow()
+	}
+	for _, malformed := range [][]byte{
+		nil,
+		data[:len(data)-1],
+		append(append([]byte{}, data...), 0),
+		append([]byte{0x05}, data[1:]...),
+		append([]byte{0x04}, make([]byte, 64)...),
+	} {
+		if x, y := c.Unmarshal(malformed); x != nil || y != nil {
+			t.Fatal("malformed point accepted")
+		}
+	}
+	data = c.MarshalCompressed(c.X, c.Y)
+	tooBig := append([]byte{0x02}, pad(c.P.Bytes(), 32)...)
+	for _, malformed := range [][]byte{
+		nil,
+		data[:len(data)-1],
+		append([]byte{0x04}, data[1:]...),
+		tooBig,
+	} {
+		if x, y := c.UnmarshalCompressed(malformed); x != nil || y != nil {
+			t.Fatal("malformed point accepted")
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"hash"
+
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+)
+
+var (
+	// Public key algorithms
+	OIDGostR34102001     = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 19}
+	OIDtc26gost341012256 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 1, 1}
+	OIDtc26gost341012512 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 1, 2}
+
+	// Digest algorithms
+	OIDGostR341194CryptoProParamSet = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 30, 1}
+	OIDtc26gost34112012256          = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 2, 2}
+	OIDtc26gost34112012512          = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 2, 3}
+
+	// Signature algorithms
+	OIDtc26SignWithDigestGost341012256 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 3, 2}
+	OIDtc26SignWithDigestGost341012512 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 3, 3}
+)
+
+// Known curves with their OIDs. The first curve with the given OID is
+// used when looking up by OID.
+var curveOIDs = []struct {
+	oid   asn1.ObjectIdentifier
+	curve func() *Curve
+}{
+	{asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 0}, CurveIdGostR34102001TestParamSet},
+	{asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 1}, CurveIdGostR34102001CryptoProAParamSet},
+	{asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 2}, CurveIdGostR34102001CryptoProBParamSet},
+	{asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 3}, CurveIdGostR34102001CryptoProCParamSet},
+	{asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 0}, CurveIdGostR34102001CryptoProXchAParamSet},
+	{asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 1}, CurveIdGostR34102001CryptoProXchBParamSet},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 1}, CurveIdtc26gost34102012256paramSetA},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 1}, CurveIdtc26gost341012256paramSetA},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 2}, CurveIdtc26gost34102012256paramSetB},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 2}, CurveIdtc26gost341012256paramSetB},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 3}, CurveIdtc26gost34102012256paramSetC},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 3}, CurveIdtc26gost341012256paramSetC},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 4}, CurveIdtc26gost34102012256paramSetD},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 4}, CurveIdtc26gost341012256paramSetD},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 0}, CurveIdtc26gost34102012512paramSetTest},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 0}, CurveIdtc26gost341012512paramSetTest},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 1}, CurveIdtc26gost34102012512paramSetA},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 1}, CurveIdtc26gost341012512paramSetA},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 2}, CurveIdtc26gost34102012512paramSetB},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 2}, CurveIdtc26gost341012512paramSetB},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 3}, CurveIdtc26gost34102012512paramSetC},
+	{asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 3}, CurveIdtc26gost341012512paramSetC},
+}
+
+// Get known curve by its OID.
+func CurveByOID(oid asn1.ObjectIdentifier) (*Curve, bool) {
+	for _, known := range curveOIDs {
+		if known.oid.Equal(oid) {
+			return known.curve(), true
+		}
+	}
+	return nil, false
+}
+
+// Get OID of the known curve with the same parameters. Several known
+// curves share parameters, so the one with the same name is preferred.
+func (c *Curve) OID() (asn1.ObjectIdentifier, bool) {
+	for _, known := range curveOIDs {
+		if k := known.curve(); k.Name == c.Name && k.Equal(c) {
+			return known.oid, true
+		}
+	}
+	for _, known := range curveOIDs {
+		if known.curve().Equal(c) {
+			return known.oid, true
+		}
+	}
+	return nil, false
+}
+
+// Public key algorithm and digest OIDs appropriate for the curve.
+func algorithmOIDs(c *Curve) (algo, digest asn1.ObjectIdentifier) {
+	if c.PointSize() == 64 {
+		return OIDtc26gost341012512, OIDtc26gost34112012512
+	}
+	return OIDtc26gost341012256, OIDtc26gost34112012256
+}
+
+// Signature algorithm OID for the key and the hash to make the digest
+// with: 34.10-2012 with Streebog of curve's point size, as X.509
+// (RFC 9215) and TLS (RFC 9189, RFC 9367) use, 34.10-2001 curves
+// included. Digest must be reversed before SignDigest, like
+// PrivateKeyReverseDigest does.
+func SignatureAlgorithm(pub *PublicKey) (oid asn1.ObjectIdentifier, hashNew func() hash.Hash) {
+	if pub.C.PointSize() == 64 {
+		return OIDtc26SignWithDigestGost341012512, gost34112012512.New
+	}
+	return OIDtc26SignWithDigestGost341012256, gost34112012256.New
+}
+
+// GostR3410-PublicKeyParameters (RFC 4491)
+type publicKeyParams struct {
+	PublicKeyParamSet asn1.ObjectIdentifier
+	DigestParamSet    asn1.ObjectIdentifier `asn1:"optional"`
+}
+
+// Make AlgorithmIdentifier for the key on the given curve. Digest
+// parameters are omitted for 512-bit curves, as RFC 9215 requires.
+func algorithmIdentifier(c *Curve) (pkix.AlgorithmIdentifier, error) {
+	curveOID, ok := c.OID()
+	if !ok {
+		return pkix.AlgorithmIdentifier{}, ErrUnknownCurve
+	}
+	algo, digest := algorithmOIDs(c)
+	params := publicKeyParams{PublicKeyParamSet: curveOID}
+	if c.PointSize() == 32 {
+		params.DigestParamSet = digest
+	}
+	der, err := asn1.Marshal(params)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  algo,
+		Parameters: asn1.RawValue{FullBytes: der},
+	}, nil
+}
+
+// Get the curve from AlgorithmIdentifier of the public key.
+func curveFromAlgorithmIdentifier(ai pkix.AlgorithmIdentifier) (*Curve, error) {
+	var pointSize int
+	switch {
+	case ai.Algorithm.Equal(OIDGostR34102001), ai.Algorithm.Equal(OIDtc26gost341012256):
+		pointSize = 32
+	case ai.Algorithm.Equal(OIDtc26gost341012512):
+		pointSize = 64
+	default:
+		return nil, errors.New("gogost/gost3410: unknown public key algorithm")
+	}
+	var params publicKeyParams
+	rest, err := asn1.Unmarshal(ai.Parameters.FullBytes, &params)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errorf(ErrTrailingData, "gogost/gost3410: trailing data after parameters")
+	}
+	c, ok := CurveByOID(params.PublicKeyParamSet)
+	if !ok {
+		return nil, ErrUnknownCurve
+	}
+	if c.PointSize() != pointSize {
+		return nil, errors.New("gogost/gost3410: curve does not match algorithm")
+	}
+	return c, nil
+}
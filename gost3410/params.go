@@ -692,3 +692,43 @@ var (
 
 	CurveDefault = CurveIdtc26gost341012256paramSetB
 )
+
+// All predefined curves, including aliases.
+var curves = []func() *Curve{
+	CurveGostR34102001ParamSetcc,
+	CurveIdGostR34102001TestParamSet,
+	CurveIdtc26gost341012256paramSetA,
+	CurveIdtc26gost341012256paramSetB,
+	CurveIdtc26gost341012256paramSetC,
+	CurveIdtc26gost341012256paramSetD,
+	CurveIdtc26gost341012512paramSetTest,
+	CurveIdtc26gost341012512paramSetA,
+	CurveIdtc26gost341012512paramSetB,
+	CurveIdtc26gost341012512paramSetC,
+	CurveIdGostR34102001CryptoProAParamSet,
+	CurveIdGostR34102001CryptoProBParamSet,
+	CurveIdGostR34102001CryptoProCParamSet,
+	CurveIdGostR34102001CryptoProXchAParamSet,
+	CurveIdGostR34102001CryptoProXchBParamSet,
+	CurveIdtc26gost34102012256paramSetA,
+	CurveIdtc26gost34102012256paramSetB,
+	CurveIdtc26gost34102012256paramSetC,
+	CurveIdtc26gost34102012256paramSetD,
+	CurveIdtc26gost34102012512paramSetTest,
+	CurveIdtc26gost34102012512paramSetA,
+	CurveIdtc26gost34102012512paramSetB,
+	CurveIdtc26gost34102012512paramSetC,
+}
+
+// Get predefined curve by its name, like
+// "id-tc26-gost-3410-12-256-paramSetB". Use Curve.Equal to find out if
+// differently named curves are the same.
+func CurveByName(name string) (*Curve, bool) {
+	for _, curve := range curves {
+		c := curve()
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,45 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"testing"
+)
+
+func TestCurveByName(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		got, ok := CurveByName(c.Name)
+		if !ok || got.Name != c.Name || !got.Equal(c) {
+			t.Fatal(c.Name)
+		}
+	}
+	if _, ok := CurveByName("id-unknown-paramSet"); ok {
+		t.FailNow()
+	}
+}
+
+func TestCurveEqualAliases(t *testing.T) {
+	if !CurveIdGostR34102001CryptoProAParamSet().Equal(CurveIdtc26gost341012256paramSetB()) {
+		t.FailNow()
+	}
+	if CurveIdtc26gost341012256paramSetB().Equal(CurveIdtc26gost341012256paramSetC()) {
+		t.FailNow()
+	}
+	if CurveIdtc26gost341012512paramSetA().Equal(CurveIdtc26gost341012256paramSetA()) {
+		t.FailNow()
+	}
+}
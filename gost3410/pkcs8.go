@@ -0,0 +1,74 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+)
+
+// PrivateKeyInfo (RFC 5208)
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+	Attributes asn1.RawValue `asn1:"optional,tag:0"`
+}
+
+// Marshal private key to PKCS#8 PrivateKeyInfo DER encoded structure.
+// Private key itself is stored as OCTET STRING with little-endian
+// Raw() representation.
+func MarshalPKCS8PrivateKey(prv *PrivateKey) ([]byte, error) {
+	ai, err := algorithmIdentifier(prv.C)
+	if err != nil {
+		return nil, err
+	}
+	key, err := asn1.Marshal(prv.Raw())
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{Algo: ai, PrivateKey: key})
+}
+
+// Parse PKCS#8 PrivateKeyInfo DER encoded structure made by
+// MarshalPKCS8PrivateKey.
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var info pkcs8
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errorf(ErrTrailingData, "gogost/gost3410: trailing data after PKCS#8")
+	}
+	if info.Version != 0 {
+		return nil, errors.New("gogost/gost3410: unknown PKCS#8 version")
+	}
+	c, err := curveFromAlgorithmIdentifier(info.Algo)
+	if err != nil {
+		return nil, err
+	}
+	var raw []byte
+	rest, err = asn1.Unmarshal(info.PrivateKey, &raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errorf(ErrTrailingData, "gogost/gost3410: trailing data after private key")
+	}
+	return NewPrivateKey(c, raw)
+}
@@ -0,0 +1,104 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+)
+
+func TestPKCS8(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdGostR34102001CryptoProAParamSet(),
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		der, err := MarshalPKCS8PrivateKey(prv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ParsePKCS8PrivateKey(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Key.Cmp(prv.Key) != 0 || !got.C.Equal(c) {
+			t.FailNow()
+		}
+		if _, err = ParsePKCS8PrivateKey(append(der, 0x00)); err == nil {
+			t.FailNow()
+		}
+	}
+}
+
+func TestPKCS8UnknownCurve(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	c, err := NewCurve(c.P, c.Q, c.A, c.B, c.X, c.Y, nil, nil, nil)
+	if err != nil {
+		t.FailNow()
+	}
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	der, err := MarshalPKCS8PrivateKey(prv)
+	if err != nil {
+		t.FailNow()
+	}
+	got, err := ParsePKCS8PrivateKey(der)
+	if err != nil || !got.C.Equal(c) {
+		t.FailNow()
+	}
+	c.B = bigInt1
+	if _, err = MarshalPKCS8PrivateKey(prv); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestPKCS8CurveMismatch(t *testing.T) {
+	prv, err := GenPrivateKey(CurveIdtc26gost341012256paramSetB(), rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	params, err := asn1.Marshal(publicKeyParams{
+		PublicKeyParamSet: asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 2},
+	})
+	if err != nil {
+		t.FailNow()
+	}
+	key, err := asn1.Marshal(prv.Raw())
+	if err != nil {
+		t.FailNow()
+	}
+	der, err := asn1.Marshal(pkcs8{
+		Algo: pkix.AlgorithmIdentifier{
+			Algorithm:  OIDtc26gost341012512,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PrivateKey: key,
+	})
+	if err != nil {
+		t.FailNow()
+	}
+	if _, err = ParsePKCS8PrivateKey(der); err == nil {
+		t.FailNow()
+	}
+}
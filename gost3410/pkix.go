@@ -0,0 +1,75 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+)
+
+// SubjectPublicKeyInfo (RFC 5280)
+type spki struct {
+	Algo      pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// Marshal public key to DER encoded SubjectPublicKeyInfo structure.
+// As RFC 4491 requires, public key is OCTET STRING with little-endian
+// Raw() representation of X||Y coordinates.
+func MarshalPKIXPublicKey(pub *PublicKey) ([]byte, error) {
+	ai, err := algorithmIdentifier(pub.C)
+	if err != nil {
+		return nil, err
+	}
+	key, err := asn1.Marshal(pub.Raw())
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(spki{
+		Algo:      ai,
+		PublicKey: asn1.BitString{Bytes: key, BitLength: 8 * len(key)},
+	})
+}
+
+// Parse DER encoded SubjectPublicKeyInfo structure with GOST R 34.10
+// public key.
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	var info spki
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errorf(ErrTrailingData, "gogost/gost3410: trailing data after SubjectPublicKeyInfo")
+	}
+	c, err := curveFromAlgorithmIdentifier(info.Algo)
+	if err != nil {
+		return nil, err
+	}
+	if info.PublicKey.BitLength != 8*len(info.PublicKey.Bytes) {
+		return nil, errors.New("gogost/gost3410: invalid public key bit string")
+	}
+	var raw []byte
+	rest, err = asn1.Unmarshal(info.PublicKey.Bytes, &raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errorf(ErrTrailingData, "gogost/gost3410: trailing data after public key")
+	}
+	return NewPublicKey(c, raw)
+}
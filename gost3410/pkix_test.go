@@ -0,0 +1,106 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+)
+
+// SubjectPublicKeyInfo with GOST R 34.10-2001 test parameters public key
+// from RFC 5832 test vector, encoded independently
+func TestPKIXVector(t *testing.T) {
+	der, _ := hex.DecodeString("" +
+		"3066" +
+		"301f" +
+		"06082a85030701010101" +
+		"3013" +
+		"06072a85030202230006082a85030701010202" +
+		"03430004" + "40" +
+		"0bd86fe5d8db89668f789b4e1dba8585c5508b45ec5b59d8906ddb70e2492b7f" +
+		"da77ff871a10fbdf2766d293c5d164afbb3c7b973a41c885d11d70d689b4f126",
+	)
+	prvRaw, _ := hex.DecodeString("283bec9198ce191dee7e39491f96601bc1729ad39d35ed10beb99b78de9a927a")
+	prv, err := NewPrivateKey(CurveIdGostR34102001TestParamSet(), prvRaw)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	got, err := MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(got, der) != 0 {
+		t.FailNow()
+	}
+	parsed, err := ParsePKIXPublicKey(der)
+	if err != nil || !parsed.Equal(pub) {
+		t.FailNow()
+	}
+}
+
+// The same key with legacy GOST R 34.10-2001 algorithm identifier
+func TestPKIXVector2001(t *testing.T) {
+	der, _ := hex.DecodeString("" +
+		"3063" +
+		"301c" +
+		"06062a8503020213" +
+		"3012" +
+		"06072a85030202230006072a850302021e01" +
+		"03430004" + "40" +
+		"0bd86fe5d8db89668f789b4e1dba8585c5508b45ec5b59d8906ddb70e2492b7f" +
+		"da77ff871a10fbdf2766d293c5d164afbb3c7b973a41c885d11d70d689b4f126",
+	)
+	pub, err := ParsePKIXPublicKey(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(pub.Raw()[:8]) != "0bd86fe5d8db8966" {
+		t.FailNow()
+	}
+}
+
+func TestPKIXRandom(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetB(),
+	} {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		der, err := MarshalPKIXPublicKey(pub)
+		if err != nil {
+			t.FailNow()
+		}
+		got, err := ParsePKIXPublicKey(der)
+		if err != nil || !got.C.Equal(c) || !got.Equal(pub) {
+			t.FailNow()
+		}
+		if _, err = ParsePKIXPublicKey(der[:len(der)-1]); err == nil {
+			t.FailNow()
+		}
+	}
+}
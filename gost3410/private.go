@@ -18,9 +18,13 @@ package gost3410
 import (
 	"crypto"
 	"errors"
-	"fmt"
 	"io"
 	"math/big"
+	"sync"
+
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+	"go.cypherpunks.ru/gogost/v5/internal/ct"
 )
 
 type PrivateKey struct {
@@ -28,36 +32,134 @@ type PrivateKey struct {
 	Key *big.Int
 }
 
-func NewPrivateKey(c *Curve, raw []byte) (*PrivateKey, error) {
+func newPrivateKey(c *Curve, raw []byte) (*big.Int, error) {
 	pointSize := c.PointSize()
 	if len(raw) != pointSize {
-		return nil, fmt.Errorf("gogost/gost3410: len(key) != %d", pointSize)
+		return nil, errorf(ErrInvalidKeyLength, "gogost/gost3410: len(key) != %d", pointSize)
 	}
-	key := make([]byte, pointSize)
-	for i := 0; i < len(key); i++ {
-		key[i] = raw[len(raw)-i-1]
+	return ScalarFromBytesLE(raw), nil
+}
+
+// Create private key from little-endian raw representation. Raw must
+// be exactly PointSize bytes long, use ParsePrivateKeyRaw for data
+// read from files. Key must be in [1, Q-1] range, use
+// NewPrivateKeyReduce to accept any value.
+func NewPrivateKey(c *Curve, raw []byte) (*PrivateKey, error) {
+	k, err := newPrivateKey(c, raw)
+	if err != nil {
+		return nil, err
 	}
-	k := bytes2big(key)
 	if k.Cmp(zero) == 0 {
-		return nil, errors.New("gogost/gost3410: zero private key")
+		return nil, ErrZeroPrivateKey
 	}
-	return &PrivateKey{c, k.Mod(k, c.Q)}, nil
+	if k.Cmp(c.Q) >= 0 {
+		return nil, errorf(ErrPrivateKeyOutOfRange, "gogost/gost3410: private key is not less than Q")
+	}
+	return &PrivateKey{c, k}, nil
 }
 
+// Create private key from little-endian raw representation, reducing
+// it modulo Q. Resulting key can differ from raw, so Raw() does not
+// return the same value. Keys being multiple of Q are rejected.
+func NewPrivateKeyReduce(c *Curve, raw []byte) (*PrivateKey, error) {
+	k, err := newPrivateKey(c, raw)
+	if err != nil {
+		return nil, err
+	}
+	k.Mod(k, c.Q)
+	if k.Cmp(zero) == 0 {
+		return nil, ErrZeroPrivateKey
+	}
+	return &PrivateKey{c, k}, nil
+}
+
+// Create private key from big-endian raw representation, as OpenSSL
+// and CryptoPro tools print it. NewPrivateKey and Raw use little-endian
+// one, so do not reverse bytes manually.
+func NewPrivateKeyBigEndian(c *Curve, raw []byte) (*PrivateKey, error) {
+	le := make([]byte, len(raw))
+	copy(le, raw)
+	reverse(le)
+	return NewPrivateKey(c, le)
+}
+
+// Parse little-endian raw private key read from a file. Single trailing
+// "\n" or "\r\n" is stripped, as editors and "echo" often append it,
+// but only if data is longer than PointSize: raw key's last byte can
+// be a newline itself. Any other length gives an error telling the
+// expected one, instead of the key silently made from wrong bytes.
+// Leading whitespace is not trimmed, as it is valid key's bytes.
+func ParsePrivateKeyRaw(c *Curve, data []byte) (*PrivateKey, error) {
+	pointSize := c.PointSize()
+	switch {
+	case len(data) == pointSize+1 && data[pointSize] == '\n':
+		data = data[:pointSize]
+	case len(data) == pointSize+2 && data[pointSize] == '\r' && data[pointSize+1] == '\n':
+		data = data[:pointSize]
+	}
+	if len(data) != pointSize {
+		return nil, errorf(
+			ErrInvalidKeyLength,
+			"gogost/gost3410: raw private key must be %d bytes, got %d",
+			pointSize, len(data),
+		)
+	}
+	return NewPrivateKey(c, data)
+}
+
+// Generate private key. Exactly PointSize bytes are read from rand
+// once, interpreted as little-endian integer (as NewPrivateKey does) and
+// reduced modulo Q. Error is returned if the result is zero, rand is
+// not read again.
 func GenPrivateKey(c *Curve, rand io.Reader) (*PrivateKey, error) {
 	raw := make([]byte, c.PointSize())
 	if _, err := io.ReadFull(rand, raw); err != nil {
 		return nil, err
 	}
-	return NewPrivateKey(c, raw)
+	return NewPrivateKeyReduce(c, raw)
+}
+
+const deriveLabel = "gogost/gost3410 DerivePrivateKey"
+
+// Deterministically derive private key from the seed. Seed is used as
+// KDF_TREE_GOSTR3411_2012_256 (RFC 7836 4.5) key with
+// "gogost/gost3410 DerivePrivateKey" label, big-endian Q as a seed and
+// one-byte counter, giving PointSize+32 bytes of keying material. It is
+// interpreted as big-endian integer d and the key is d mod (Q-1) + 1,
+// so it is always in [1, Q-1] range and the bias is negligible (less
+// than 2^-256). The same seed always gives the same key on the same
+// curve and unrelated keys on different ones. Seed must be at least 16
+// bytes long and should be kept as secret as the key itself.
+func DerivePrivateKey(c *Curve, seed []byte) (*PrivateKey, error) {
+	if len(seed) < 16 {
+		return nil, errors.New("gogost/gost3410: seed is too short")
+	}
+	raw := gost34112012256.NewKDF(seed).DeriveTree(
+		nil, []byte(deriveLabel), c.Q.Bytes(),
+		c.PointSize()/gost34112012256.Size+1, 1,
+	)
+	d := bytes2big(raw)
+	for i := range raw {
+		raw[i] = 0
+	}
+	qm1 := big.NewInt(0).Sub(c.Q, bigInt1)
+	d.Mod(d, qm1)
+	d.Add(d, bigInt1)
+	return &PrivateKey{c, d}, nil
 }
 
 func (prv *PrivateKey) Raw() []byte {
-	raw := pad(prv.Key.Bytes(), prv.C.PointSize())
-	reverse(raw)
-	return raw
+	return ScalarToBytesLE(prv.Key, prv.C.PointSize())
 }
 
+// Big-endian raw representation of the key, as
+// NewPrivateKeyBigEndian expects.
+func (prv *PrivateKey) RawBigEndian() []byte {
+	return pad(prv.Key.Bytes(), prv.C.PointSize())
+}
+
+// Derive public key. Error is returned if key multiplies the base point
+// to the point at infinity (key is a multiple of Q).
 func (prv *PrivateKey) PublicKey() (*PublicKey, error) {
 	x, y, err := prv.C.Exp(prv.Key, prv.C.X, prv.C.Y)
 	if err != nil {
@@ -66,50 +168,334 @@ func (prv *PrivateKey) PublicKey() (*PublicKey, error) {
 	return &PublicKey{prv.C, x, y}, nil
 }
 
+// Check that the key and its curve are usable: key is in [1, Q-1]
+// range, base point has order Q, and derived public key is on the
+// curve and is not the point at infinity. It is useful for keys
+// created with curve parameters from untrusted source.
+func (prv *PrivateKey) Validate() error {
+	c := prv.C
+	if prv.Key.Sign() <= 0 || prv.Key.Cmp(c.Q) >= 0 {
+		return ErrPrivateKeyOutOfRange
+	}
+	if !c.contains(c.X, c.Y) {
+		return errorf(ErrInvalidCurve, "gogost/gost3410: base point is not on the curve")
+	}
+	if _, _, err := c.Exp(c.Q, c.X, c.Y); err == nil {
+		return errorf(ErrInvalidCurve, "gogost/gost3410: base point order is not Q")
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		return err
+	}
+	if !c.contains(pub.X, pub.Y) {
+		return errorf(ErrPointNotOnCurve, "gogost/gost3410: public key is not on the curve")
+	}
+	return nil
+}
+
+// Sign the digest, taking k from rand. Digest of any length is
+// interpreted as big-endian integer and reduced modulo Q, zero being
+// replaced with one. Use Sign for strict digest length check and
+// SignDigestTruncate for digests longer than the point size.
+//
+// Each attempt reads exactly PointSize bytes, interprets them as
+// big-endian integer and reduces it modulo Q. The attempt is repeated
+// with newly read bytes if k, r or s is zero, so a fixed reader with
+// precomputed k reproduces signature only if that k is suitable; use
+// SignDigestWithK for test vectors.
+//
+// Multiplications with the secret key are blinded:
+// s = b^-1 * ((b*d)*r + (b*k)*e) mod q, with b being Streebog-512 of
+// the key, k and e reduced modulo q. Blinding does not change the
+// signature, does not read from rand and needs no other entropy
+// source.
 func (prv *PrivateKey) SignDigest(digest []byte, rand io.Reader) ([]byte, error) {
-	e := bytes2big(digest)
-	e.Mod(e, prv.C.Q)
-	if e.Cmp(zero) == 0 {
-		e = big.NewInt(1)
+	return prv.signDigest(digest, rand, true)
+}
+
+// Sign the digest like SignDigest does, returning the structured
+// signature.
+func (prv *PrivateKey) SignDigestSig(digest []byte, rand io.Reader) (*Signature, error) {
+	sig, err := prv.SignDigest(digest, rand)
+	if err != nil {
+		return nil, err
+	}
+	return NewSignature(prv.C, sig)
+}
+
+// Sign the digest with explicitly given k from [1, Q-1] range, as
+// standard test vectors do. Never use the same k twice: private key is
+// trivially recoverable from two such signatures.
+func (prv *PrivateKey) SignDigestWithK(digest []byte, k *big.Int) ([]byte, error) {
+	if prv.Key.Sign() == 0 {
+		return nil, ErrZeroPrivateKey
+	}
+	if k.Sign() <= 0 || k.Cmp(prv.C.Q) >= 0 {
+		return nil, errors.New("gogost/gost3410: k is out of range")
 	}
-	kRaw := make([]byte, prv.C.PointSize())
+	sc := getSignScratch(prv.C.PointSize())
+	defer putSignScratch(sc)
+	setDigestE(&sc.e, digest, prv.C.Q)
+	sc.k.Set(k)
+	sign, err := prv.signWithK(sc, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	if sign == nil {
+		return nil, errors.New("gogost/gost3410: unsuitable k")
+	}
+	return sign, nil
+}
+
+// Keep only the last (least significant) PointSize bytes of longer
+// digest, like reducing it modulo 2^(8*PointSize). Shorter digests are
+// returned as is.
+func truncateDigest(c *Curve, digest []byte) []byte {
+	pointSize := c.PointSize()
+	if len(digest) > pointSize {
+		return digest[len(digest)-pointSize:]
+	}
+	return digest
+}
+
+// Sign the digest truncated to the curve's point size, so for example
+// 512-bit Streebog digest can be signed with 256-bit curve's key.
+// Leading (most significant) bytes of longer digest are dropped,
+// shorter digest is used as is. Signature must be verified with
+// VerifyDigestTruncate. For digests not longer than the point size it
+// is the same as SignDigest.
+func (prv *PrivateKey) SignDigestTruncate(digest []byte, rand io.Reader) ([]byte, error) {
+	return prv.SignDigest(truncateDigest(prv.C, digest), rand)
+}
+
+// Set e to digest reduced modulo q, zero being replaced with one.
+// Both signing and verification must use it for the same result.
+func setDigestE(e *big.Int, digest []byte, q *big.Int) *big.Int {
+	e.SetBytes(digest)
+	e.Mod(e, q)
+	if e.Sign() == 0 {
+		e.SetInt64(1)
+	}
+	return e
+}
+
+// Scratch values for signing. They are pooled so repeated signing does
+// not allocate them on each call: only Exp's result and the signature
+// itself are allocated.
+type signScratch struct {
+	e, k, d, s, b big.Int
+	raw           []byte
+}
+
+var signScratchPool = sync.Pool{New: func() interface{} {
+	return new(signScratch)
+}}
+
+func getSignScratch(pointSize int) *signScratch {
+	sc := signScratchPool.Get().(*signScratch)
+	if cap(sc.raw) < pointSize {
+		sc.raw = make([]byte, pointSize)
+	}
+	sc.raw = sc.raw[:pointSize]
+	return sc
+}
+
+func zeroInt(v *big.Int) {
+	words := v.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	v.SetInt64(0)
+}
+
+// Zero secret values, as best effort, and return scratch to the pool.
+func putSignScratch(sc *signScratch) {
+	zeroInt(&sc.k)
+	zeroInt(&sc.d)
+	zeroInt(&sc.s)
+	zeroInt(&sc.b)
+	for i := range sc.raw {
+		sc.raw[i] = 0
+	}
+	signScratchPool.Put(sc)
+}
+
+// Sign without blinding if blind is false.
+func (prv *PrivateKey) signDigest(digest []byte, rand io.Reader, blind bool) ([]byte, error) {
+	return prv.signDigestPre(digest, rand, blind, nil)
+}
+
+// Sign using key-dependent precomputations, if pre is not nil.
+func (prv *PrivateKey) signDigestPre(digest []byte, rand io.Reader, blind bool, pre *signPre) ([]byte, error) {
+	if prv.Key.Sign() == 0 {
+		return nil, ErrZeroPrivateKey
+	}
+	sc := getSignScratch(prv.C.PointSize())
+	defer putSignScratch(sc)
+	setDigestE(&sc.e, digest, prv.C.Q)
 	var err error
-	var k *big.Int
-	var r *big.Int
-	d := big.NewInt(0)
-	s := big.NewInt(0)
+	var sign []byte
 Retry:
-	if _, err = io.ReadFull(rand, kRaw); err != nil {
+	if _, err = io.ReadFull(rand, sc.raw); err != nil {
 		return nil, err
 	}
-	k = bytes2big(kRaw)
-	k.Mod(k, prv.C.Q)
-	if k.Cmp(zero) == 0 {
+	sc.k.SetBytes(sc.raw)
+	sc.k.Mod(&sc.k, prv.C.Q)
+	if sc.k.Sign() == 0 {
 		goto Retry
 	}
-	r, _, err = prv.C.Exp(k, prv.C.X, prv.C.Y)
+	sign, err = prv.signWithK(sc, blind, pre)
 	if err != nil {
 		return nil, err
 	}
-	r.Mod(r, prv.C.Q)
-	if r.Cmp(zero) == 0 {
+	if sign == nil {
 		goto Retry
 	}
-	d.Mul(prv.Key, r)
-	k.Mul(k, e)
-	s.Add(d, k)
-	s.Mod(s, prv.C.Q)
-	if s.Cmp(zero) == 0 {
-		goto Retry
+	return sign, nil
+}
+
+// Set b to the blinding factor: Streebog-512 of the key, k and e,
+// reduced modulo q, one if it is zero. It is unpredictable without the
+// key and k, is fresh for every new k and needs no entropy source, so
+// deterministic and fixed k signing work without it. raw is a buffer
+// of PointSize bytes.
+func (prv *PrivateKey) blindScalar(b, k, e *big.Int, raw []byte) {
+	h := gost34112012512.New()
+	for _, v := range []*big.Int{prv.Key, k, e} {
+		for i := range raw {
+			raw[i] = 0
+		}
+		vBytes := v.Bytes()
+		copy(raw[len(raw)-len(vBytes):], vBytes)
+		h.Write(raw)
+		for i := range vBytes {
+			vBytes[i] = 0
+		}
+	}
+	for i := range raw {
+		raw[i] = 0
+	}
+	b.SetBytes(h.Sum(raw[:0]))
+	b.Mod(b, prv.C.Q)
+	if b.Sign() == 0 {
+		b.SetInt64(1)
+	}
+}
+
+// Make s||r signature with sc.k and sc.e, k is overwritten. Nil
+// signature without an error is returned if r or s is zero and another
+// k must be tried. pre, if not nil, must be made for prv.
+func (prv *PrivateKey) signWithK(sc *signScratch, blind bool, pre *signPre) ([]byte, error) {
+	e, k, d, s := &sc.e, &sc.k, &sc.d, &sc.s
+	var r *big.Int
+	if pre != nil && pre.table != nil {
+		var ok bool
+		r, _, ok = prv.C.expBaseTable(pre.table, k)
+		if !ok {
+			return nil, ErrPointAtInfinity
+		}
+	} else {
+		var err error
+		r, _, err = prv.C.Exp(k, prv.C.X, prv.C.Y)
+		if err != nil {
+			return nil, err
+		}
+	}
+	r.Mod(r, prv.C.Q)
+	if r.Sign() == 0 {
+		return nil, nil
+	}
+	if fq := prv.C.fq; fq != nil && fq.pBig == prv.C.Q {
+		var key fe
+		if pre != nil {
+			key = pre.key
+		} else {
+			key = fq.fromReduced(prv.Key)
+		}
+		var b *big.Int
+		if blind {
+			b = &sc.b
+			prv.blindScalar(b, k, e, sc.raw)
+		}
+		s = signScalar(fq, &key, k, e, r, b)
+		key.clear()
+	} else if !blind {
+		d.Mul(prv.Key, r)
+		k.Mul(k, e)
+		s.Add(d, k)
+		s.Mod(s, prv.C.Q)
+	} else {
+		b := &sc.b
+		prv.blindScalar(b, k, e, sc.raw)
+		d.Mul(prv.Key, b)
+		d.Mod(d, prv.C.Q)
+		d.Mul(d, r)
+		k.Mul(k, b)
+		k.Mod(k, prv.C.Q)
+		k.Mul(k, e)
+		s.Add(d, k)
+		b.ModInverse(b, prv.C.Q)
+		s.Mul(s, b)
+		s.Mod(s, prv.C.Q)
+	}
+	if s.Sign() == 0 {
+		return nil, nil
 	}
 	pointSize := prv.C.PointSize()
-	return append(
-		pad(s.Bytes(), pointSize),
-		pad(r.Bytes(), pointSize)...,
-	), nil
+	sign := make([]byte, 2*pointSize)
+	sBytes := s.Bytes()
+	copy(sign[pointSize-len(sBytes):], sBytes)
+	rBytes := r.Bytes()
+	copy(sign[2*pointSize-len(rBytes):], rBytes)
+	return sign, nil
 }
 
+// Compute s = (r*d + k*e) mod Q with fixed-size Montgomery form
+// scalars, that are explicitly cleared afterwards, so no math/big
+// copies of the key and k are left. key is d in Montgomery form. If b
+// is not nil, then s = ((r*d*b) + (k*b*e)) * b^-1 is computed, like
+// math/big path does. All values must be already reduced modulo Q.
+func signScalar(fq *field, key *fe, k, e, r, b *big.Int) *big.Int {
+	d := *key
+	kk := fq.fromReduced(k)
+	ee := fq.fromReduced(e)
+	rr := fq.fromReduced(r)
+	var bb, bInv fe
+	if b != nil {
+		bb = fq.fromReduced(b)
+		fq.mul(&d, &d, &bb)
+		fq.mul(&kk, &kk, &bb)
+	}
+	fq.mul(&d, &d, &rr)
+	fq.mul(&kk, &kk, &ee)
+	fq.add(&d, &d, &kk)
+	if b != nil {
+		fq.inv(&bInv, &bb)
+		fq.mul(&d, &d, &bInv)
+	}
+	s := fq.toBig(&d)
+	d.clear()
+	kk.clear()
+	ee.clear()
+	bb.clear()
+	bInv.clear()
+	return s
+}
+
+// crypto.Signer compatible signing, opts are ignored. Digest is the
+// big-endian integer value, that is reduced modulo Q, as the standard
+// defines e, so it may be shorter than DigestSizeForCurve bytes: some
+// tools give minimal encoding without leading zero bytes, that is the
+// same value as the zero padded one. Longer and empty digests are
+// rejected.
 func (prv *PrivateKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	digestSize := DigestSizeForCurve(prv.C)
+	if len(digest) > digestSize {
+		return nil, errorf(ErrInvalidDigestLength, "gogost/gost3410: len(digest) > %d", digestSize)
+	}
+	if len(digest) == 0 {
+		return nil, errorf(ErrInvalidDigestLength, "gogost/gost3410: empty digest")
+	}
 	return prv.SignDigest(digest, rand)
 }
 
@@ -121,6 +507,35 @@ func (prv *PrivateKey) Public() crypto.PublicKey {
 	return pub
 }
 
+// Are private keys equal. Secret values are compared in constant time.
+// Keys on different curves are not equal.
+func (our *PrivateKey) Equal(theirKey crypto.PrivateKey) bool {
+	their, ok := theirKey.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	if !our.C.Equal(their.C) {
+		return false
+	}
+	pointSize := our.C.PointSize()
+	return ct.Eq(
+		pad(our.Key.Bytes(), pointSize),
+		pad(their.Key.Bytes(), pointSize),
+	)
+}
+
+// Overwrite private key's value with zeros. Key is unusable after that:
+// signing, public key derivation and key agreement return an error.
+// math/big does not guarantee that no other copies of secret were left
+// during arithmetic operations, so that is only best effort.
+func (prv *PrivateKey) Zero() {
+	words := prv.Key.Bits()
+	for i := 0; i < len(words); i++ {
+		words[i] = 0
+	}
+	prv.Key.SetInt64(0)
+}
+
 type PrivateKeyReverseDigest struct {
 	Prv *PrivateKey
 }
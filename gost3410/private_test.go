@@ -16,17 +16,446 @@
 package gost3410
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"strings"
 	"testing"
 )
 
 func TestSignerInterface(t *testing.T) {
 	prvRaw := make([]byte, 32)
 	rand.Read(prvRaw)
-	prv, err := NewPrivateKey(CurveIdGostR34102001TestParamSet(), prvRaw)
+	prv, err := NewPrivateKeyReduce(CurveIdGostR34102001TestParamSet(), prvRaw)
 	if err != nil {
 		t.FailNow()
 	}
 	var _ crypto.Signer = prv
 }
+
+func TestSignerSign(t *testing.T) {
+	c := CurveIdGostR34102001TestParamSet()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	digest := make([]byte, 32)
+	rand.Read(digest)
+	var signer crypto.Signer = prv
+	sign, err := signer.Sign(rand.Reader, digest, nil)
+	if err != nil {
+		t.FailNow()
+	}
+	valid, err := signer.Public().(*PublicKey).VerifyDigest(digest, sign)
+	if err != nil || !valid {
+		t.FailNow()
+	}
+	if _, err = signer.Sign(rand.Reader, append(digest, 0x00), nil); err == nil {
+		t.FailNow()
+	}
+	if _, err = signer.Sign(rand.Reader, nil, nil); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestSignerSignShortDigest(t *testing.T) {
+	c := CurveIdtc26gost341012512paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	digest := make([]byte, 64)
+	rand.Read(digest[3:])
+	digest[3] |= 0x80
+	sign, err := prv.Sign(rand.Reader, digest[3:], nil)
+	if err != nil {
+		t.FailNow()
+	}
+	valid, err := pub.VerifyDigest(digest, sign)
+	if err != nil || !valid {
+		t.FailNow()
+	}
+	valid, err = pub.VerifyDigest(digest[3:], sign)
+	if err != nil || !valid {
+		t.FailNow()
+	}
+	sign, err = prv.Sign(rand.Reader, digest, nil)
+	if err != nil {
+		t.FailNow()
+	}
+	valid, err = pub.VerifyDigest(digest[3:], sign)
+	if err != nil || !valid {
+		t.FailNow()
+	}
+}
+
+func TestPrivateKeyEqual(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prvRaw := make([]byte, 32)
+	rand.Read(prvRaw)
+	prv1, err := NewPrivateKeyReduce(c, prvRaw)
+	if err != nil {
+		t.FailNow()
+	}
+	prv2, err := NewPrivateKeyReduce(CurveIdtc26gost341012256paramSetB(), prvRaw)
+	if err != nil {
+		t.FailNow()
+	}
+	if !prv1.Equal(prv2) || !prv2.Equal(prv1) {
+		t.FailNow()
+	}
+	prv3, err := NewPrivateKeyReduce(CurveIdtc26gost341012256paramSetC(), prvRaw)
+	if err != nil {
+		t.FailNow()
+	}
+	if prv1.Equal(prv3) {
+		t.FailNow()
+	}
+	prvRaw[0] ^= 0x01
+	prv3, err = NewPrivateKeyReduce(c, prvRaw)
+	if err != nil {
+		t.FailNow()
+	}
+	if prv1.Equal(prv3) {
+		t.FailNow()
+	}
+	pub, err := prv1.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	if prv1.Equal(pub) {
+		t.FailNow()
+	}
+	prv3, err = NewPrivateKeyReduce(CurveIdtc26gost341012512paramSetA(), append(prvRaw, prvRaw...))
+	if err != nil {
+		t.FailNow()
+	}
+	if prv1.Equal(prv3) || prv3.Equal(prv1) {
+		t.FailNow()
+	}
+}
+
+func TestPrivateKeyZero(t *testing.T) {
+	c := CurveIdtc26gost341012512paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	words := prv.Key.Bits()
+	prv.Zero()
+	for _, w := range words {
+		if w != 0 {
+			t.FailNow()
+		}
+	}
+	if bytes.Compare(prv.Raw(), make([]byte, c.PointSize())) != 0 {
+		t.FailNow()
+	}
+	if _, err = prv.SignDigest(make([]byte, c.PointSize()), rand.Reader); err == nil {
+		t.FailNow()
+	}
+	if _, err = prv.PublicKey(); err == nil {
+		t.FailNow()
+	}
+	if _, err = prv.KEK(pub, bigInt1); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestSignDigestBlinding(t *testing.T) {
+	c := CurveIdtc26gost341012512paramSetB()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	digest := make([]byte, c.PointSize())
+	rand.Read(digest)
+	rnd := make([]byte, c.PointSize())
+	rand.Read(rnd)
+	blinded, err := prv.signDigest(digest, bytes.NewReader(rnd), true)
+	if err != nil {
+		t.FailNow()
+	}
+	unblinded, err := prv.signDigest(digest, bytes.NewReader(rnd), false)
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(blinded, unblinded) != 0 {
+		t.FailNow()
+	}
+	valid, err := pub.VerifyDigest(digest, blinded)
+	if err != nil || !valid {
+		t.FailNow()
+	}
+}
+
+func TestSignZeroDigest(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		zeroDigest := make([]byte, c.PointSize())
+		oneDigest := make([]byte, c.PointSize())
+		oneDigest[len(oneDigest)-1] = 1
+		qDigest := pad(c.Q.Bytes(), c.PointSize())
+		sign, err := prv.SignDigest(zeroDigest, rand.Reader)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		for _, digest := range [][]byte{zeroDigest, oneDigest, qDigest} {
+			valid, err := pub.VerifyDigest(digest, sign)
+			if err != nil || !valid {
+				t.Fatal(c.Name, "zero digest signature is not verified")
+			}
+		}
+		sign, err = prv.SignDigest(oneDigest, rand.Reader)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		valid, err := pub.VerifyDigest(zeroDigest, sign)
+		if err != nil || !valid {
+			t.Fatal(c.Name, "one digest signature is not verified")
+		}
+	}
+}
+
+func TestNewPrivateKeyRange(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetC()
+	raw := func(k *big.Int) []byte {
+		r := pad(k.Bytes(), c.PointSize())
+		reverse(r)
+		return r
+	}
+	qPlus1 := big.NewInt(0).Add(c.Q, bigInt1)
+	for _, k := range []*big.Int{zero, c.Q, qPlus1} {
+		if _, err := NewPrivateKey(c, raw(k)); err == nil {
+			t.Fatal(k)
+		}
+	}
+	qMinus1 := big.NewInt(0).Sub(c.Q, bigInt1)
+	prv, err := NewPrivateKey(c, raw(qMinus1))
+	if err != nil || prv.Key.Cmp(qMinus1) != 0 {
+		t.FailNow()
+	}
+	if _, err = NewPrivateKeyReduce(c, raw(c.Q)); err == nil {
+		t.FailNow()
+	}
+	prv, err = NewPrivateKeyReduce(c, raw(qPlus1))
+	if err != nil || prv.Key.Cmp(bigInt1) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestPrivateKeyBigEndian(t *testing.T) {
+	// GOST R 34.10-2012 appendix A.1 key, as big-endian tools print it
+	c := CurveIdGostR34102001TestParamSet()
+	prvRaw, _ := hex.DecodeString("7a929ade789bb9be10ed359dd39a72c11b60961f49397eee1d19ce9891ec3b28")
+	x, _ := hex.DecodeString("7f2b49e270db6d90d8595bec458b50c58585ba1d4e9b788f6689dbd8e56fd80b")
+	y, _ := hex.DecodeString("26f1b489d6701dd185c8413a977b3cbbaf64d1c593d26627dffb101a87ff77da")
+	prv, err := NewPrivateKeyBigEndian(c, prvRaw)
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(prv.RawBigEndian(), prvRaw) != 0 {
+		t.FailNow()
+	}
+	le := prv.Raw()
+	reverse(le)
+	if bytes.Compare(le, prvRaw) != 0 {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	pubBE, err := NewPublicKeyBigEndian(c, x, y)
+	if err != nil {
+		t.FailNow()
+	}
+	if !pub.Equal(pubBE) {
+		t.FailNow()
+	}
+	if _, err = NewPublicKeyBigEndian(c, y, x); err == nil {
+		t.FailNow()
+	}
+	if _, err = NewPublicKeyBigEndian(c, x[1:], y); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestPrivateKeyValidate(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = prv.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	brokenB := *c
+	brokenB.B = big.NewInt(0).Add(c.B, bigInt1)
+	if err = (&PrivateKey{&brokenB, prv.Key}).Validate(); err == nil {
+		t.FailNow()
+	}
+
+	brokenQ := *c
+	brokenQ.Q = big.NewInt(0).Add(c.Q, bigInt2)
+	if err = (&PrivateKey{&brokenQ, prv.Key}).Validate(); err == nil {
+		t.FailNow()
+	}
+
+	if err = (&PrivateKey{c, big.NewInt(0).Set(c.Q)}).Validate(); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestSignDigestTruncate(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetA(),
+	} {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, _ := prv.PublicKey()
+		pointSize := c.PointSize()
+		for _, size := range []int{20, pointSize, 64, 80} {
+			digest := make([]byte, size)
+			rand.Read(digest)
+			sig, err := prv.SignDigestTruncate(digest, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			valid, err := pub.VerifyDigestTruncate(digest, sig)
+			if err != nil || !valid {
+				t.Fatal(c.Name, size)
+			}
+			truncated := digest
+			if size > pointSize {
+				truncated = digest[size-pointSize:]
+			}
+			valid, err = pub.VerifyDigest(truncated, sig)
+			if err != nil || !valid {
+				t.Fatal(c.Name, size)
+			}
+			if size > pointSize {
+				digest[size-pointSize-1] ^= 0xFF
+				if valid, _ = pub.VerifyDigestTruncate(digest, sig); !valid {
+					t.Fatal("leading bytes must be ignored")
+				}
+				digest[size-1] ^= 0xFF
+				if valid, _ = pub.VerifyDigestTruncate(digest, sig); valid {
+					t.FailNow()
+				}
+			}
+		}
+		if _, err = prv.Sign(rand.Reader, make([]byte, pointSize+1), nil); err == nil {
+			t.Fatal("strict Sign must reject mismatched digest")
+		}
+	}
+}
+
+func TestDerivePrivateKey(t *testing.T) {
+	seed := []byte("0123456789abcdef0123456789abcdef")
+	for _, c := range []*Curve{
+		CurveIdGostR34102001CryptoProAParamSet(),
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetA(),
+	} {
+		prv1, err := DerivePrivateKey(c, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prv2, err := DerivePrivateKey(c, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !prv1.Equal(prv2) {
+			t.Fatal("same seed gave different keys")
+		}
+		if err = prv1.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		other := append([]byte{}, seed...)
+		other[0] ^= 1
+		prv3, err := DerivePrivateKey(c, other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prv1.Key.Cmp(prv3.Key) == 0 {
+			t.Fatal("different seeds gave the same key")
+		}
+	}
+	c1 := CurveIdGostR34102001CryptoProAParamSet()
+	c2 := CurveIdGostR34102001CryptoProBParamSet()
+	prv1, _ := DerivePrivateKey(c1, seed)
+	prv2, _ := DerivePrivateKey(c2, seed)
+	if prv1.Key.Cmp(prv2.Key) == 0 {
+		t.Fatal("different curves gave the same key")
+	}
+	if _, err := DerivePrivateKey(c1, seed[:15]); err == nil {
+		t.Fatal("short seed accepted")
+	}
+}
+
+func TestParsePrivateKeyRaw(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	raw := make([]byte, 32)
+	rand.Read(raw)
+	raw[31] = 0x0A
+	raw[0] = 0x20
+	expected, err := NewPrivateKeyReduce(c, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw = expected.Raw()
+	for _, data := range [][]byte{
+		raw,
+		append(append([]byte{}, raw...), '\n'),
+		append(append([]byte{}, raw...), '\r', '\n'),
+	} {
+		prv, err := ParsePrivateKeyRaw(c, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !prv.Equal(expected) {
+			t.Fatal("different key")
+		}
+	}
+	for _, data := range [][]byte{
+		raw[:31],
+		append(append([]byte{}, raw...), ' '),
+		append(append([]byte{}, raw...), '\n', '\n'),
+		make([]byte, 64),
+	} {
+		_, err := ParsePrivateKeyRaw(c, data)
+		if err == nil {
+			t.Fatal("invalid length accepted", len(data))
+		}
+		if !strings.Contains(err.Error(), "must be 32 bytes") {
+			t.Fatal("unclear error", err)
+		}
+	}
+}
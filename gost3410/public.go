@@ -17,8 +17,11 @@ package gost3410
 
 import (
 	"crypto"
-	"fmt"
+	"encoding/asn1"
+	"encoding/hex"
 	"math/big"
+
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
 )
 
 type PublicKey struct {
@@ -31,16 +34,41 @@ func NewPublicKey(c *Curve, raw []byte) (*PublicKey, error) {
 	pointSize := c.PointSize()
 	key := make([]byte, 2*pointSize)
 	if len(raw) != len(key) {
-		return nil, fmt.Errorf("gogost/gost3410: len(key) != %d", len(key))
+		return nil, errorf(ErrInvalidKeyLength, "gogost/gost3410: len(key) != %d", len(key))
 	}
 	for i := 0; i < len(key); i++ {
 		key[i] = raw[len(raw)-i-1]
 	}
-	return &PublicKey{
+	pub := PublicKey{
 		c,
 		bytes2big(key[pointSize : 2*pointSize]),
 		bytes2big(key[:pointSize]),
-	}, nil
+	}
+	if !pub.IsOnCurve() {
+		return nil, ErrPointNotOnCurve
+	}
+	if !c.inSubgroup(pub.X, pub.Y) {
+		return nil, ErrPointNotInSubgroup
+	}
+	return &pub, nil
+}
+
+// Create public key from big-endian X and Y coordinates, as OpenSSL and
+// CryptoPro tools print them. NewPublicKey expects raw X||Y
+// representation, each little-endian, instead.
+func NewPublicKeyBigEndian(c *Curve, x, y []byte) (*PublicKey, error) {
+	pointSize := c.PointSize()
+	if len(x) != pointSize || len(y) != pointSize {
+		return nil, errorf(ErrInvalidKeyLength, "gogost/gost3410: coordinates must be %d bytes", pointSize)
+	}
+	pub := PublicKey{c, bytes2big(x), bytes2big(y)}
+	if !pub.IsOnCurve() {
+		return nil, ErrPointNotOnCurve
+	}
+	if !c.inSubgroup(pub.X, pub.Y) {
+		return nil, ErrPointNotInSubgroup
+	}
+	return &pub, nil
 }
 
 func (pub *PublicKey) Raw() []byte {
@@ -53,33 +81,86 @@ func (pub *PublicKey) Raw() []byte {
 	return raw
 }
 
-func (pub *PublicKey) VerifyDigest(digest, signature []byte) (bool, error) {
+// Is the key's point on its curve, see Curve.IsOnCurve. Subgroup
+// membership is not checked.
+func (pub *PublicKey) IsOnCurve() bool {
+	return pub.C.IsOnCurve(pub.X, pub.Y)
+}
+
+// Stable 32-byte key identifier: Streebog-256 hash of the
+// subjectPublicKey BIT STRING value of key's SubjectPublicKeyInfo, that
+// is DER encoded OCTET STRING with Raw representation. It is RFC 5280
+// 4.2.1.2 method (1) of SubjectKeyIdentifier computation with
+// Streebog-256 instead of SHA-1, so it matches identifiers of
+// certificates made that way, but not SHA-1 based ones, like x509
+// package generates. Curve is not included: equal points on different
+// curves give the same identifier.
+func (pub *PublicKey) KeyID() []byte {
+	key, err := asn1.Marshal(pub.Raw())
+	if err != nil {
+		panic(err)
+	}
+	h := gost34112012256.New()
+	h.Write(key)
+	return h.Sum(nil)
+}
+
+// Hexadecimal KeyID.
+func (pub *PublicKey) String() string {
+	return hex.EncodeToString(pub.KeyID())
+}
+
+// Parse signature and compute z1 and z2 values for the verification.
+// Nil r is returned if signature is invalid.
+func (pub *PublicKey) verifyScalars(digest, signature []byte) (r, z1, z2 *big.Int, err error) {
 	pointSize := pub.C.PointSize()
 	if len(signature) != 2*pointSize {
-		return false, fmt.Errorf("gogost/gost3410: len(signature) != %d", 2*pointSize)
+		err = errorf(ErrInvalidSignatureLength, "gogost/gost3410: len(signature) != %d", 2*pointSize)
+		return
 	}
 	s := bytes2big(signature[:pointSize])
-	r := bytes2big(signature[pointSize:])
+	r = bytes2big(signature[pointSize:])
 	if r.Cmp(zero) <= 0 ||
 		r.Cmp(pub.C.Q) >= 0 ||
 		s.Cmp(zero) <= 0 ||
 		s.Cmp(pub.C.Q) >= 0 {
-		return false, nil
-	}
-	e := bytes2big(digest)
-	e.Mod(e, pub.C.Q)
-	if e.Cmp(zero) == 0 {
-		e = big.NewInt(1)
+		r = nil
+		return
 	}
+	e := setDigestE(big.NewInt(0), digest, pub.C.Q)
 	v := big.NewInt(0)
 	v.ModInverse(e, pub.C.Q)
-	z1 := big.NewInt(0)
-	z2 := big.NewInt(0)
+	z1 = big.NewInt(0)
+	z2 = big.NewInt(0)
 	z1.Mul(s, v)
 	z1.Mod(z1, pub.C.Q)
 	z2.Mul(r, v)
 	z2.Mod(z2, pub.C.Q)
 	z2.Sub(pub.C.Q, z2)
+	return
+}
+
+// Verify signature made with SignDigest. Digest is reduced modulo Q
+// the same way SignDigest does, zero being replaced with one, so
+// signatures of all-zero digests (and digests being multiple of Q)
+// verify. Public key outside of the Q order
+// subgroup gives an error: on curves with the cofactor greater than 1
+// it is checked on every verification, as the key could be constructed
+// without NewPublicKey.
+func (pub *PublicKey) VerifyDigest(digest, signature []byte) (bool, error) {
+	r, z1, z2, err := pub.verifyScalars(digest, signature)
+	if err != nil || r == nil {
+		return false, err
+	}
+	if pub.X == nil || pub.Y == nil {
+		return false, errorf(ErrPointAtInfinity, "gogost/gost3410: public key is the point at infinity")
+	}
+	if !pub.C.contains(pub.X, pub.Y) {
+		return false, ErrPointNotOnCurve
+	}
+	if !pub.C.inSubgroup(pub.X, pub.Y) {
+		return false, ErrPointNotInSubgroup
+	}
 	p1x, p1y, err := pub.C.Exp(z1, pub.C.X, pub.C.Y)
 	if err != nil {
 		return false, err
@@ -109,6 +190,28 @@ func (pub *PublicKey) VerifyDigest(digest, signature []byte) (bool, error) {
 	return lm.Cmp(r) == 0, nil
 }
 
+// Verify structured signature. Signature values out of the curve's
+// range make it invalid.
+func (pub *PublicKey) Verify(digest []byte, sig *Signature) bool {
+	if sig == nil || sig.R == nil || sig.S == nil ||
+		sig.R.Sign() <= 0 || sig.R.Cmp(pub.C.Q) >= 0 ||
+		sig.S.Sign() <= 0 || sig.S.Cmp(pub.C.Q) >= 0 {
+		return false
+	}
+	raw, err := sig.BytesFor(pub.C)
+	if err != nil {
+		return false
+	}
+	valid, err := pub.VerifyDigest(digest, raw)
+	return err == nil && valid
+}
+
+// Verify signature made with SignDigestTruncate.
+func (pub *PublicKey) VerifyDigestTruncate(digest, signature []byte) (bool, error) {
+	return pub.VerifyDigest(truncateDigest(pub.C, digest), signature)
+}
+
+// Are public keys equal. Keys on different curves are not equal.
 func (our *PublicKey) Equal(theirKey crypto.PublicKey) bool {
 	their, ok := theirKey.(*PublicKey)
 	if !ok {
@@ -0,0 +1,229 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+)
+
+func TestPublicKeyRaw(t *testing.T) {
+	f := func(c *Curve) {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		raw := pub.Raw()
+		if len(raw) != 2*c.PointSize() {
+			t.FailNow()
+		}
+		pub2, err := NewPublicKey(c, raw)
+		if err != nil || !pub.Equal(pub2) {
+			t.FailNow()
+		}
+		if bytes.Compare(pub2.Raw(), raw) != 0 {
+			t.FailNow()
+		}
+		if _, err = NewPublicKey(c, raw[1:]); err == nil {
+			t.FailNow()
+		}
+		if _, err = NewPublicKey(c, append(raw, 0x00)); err == nil {
+			t.FailNow()
+		}
+		raw[0] ^= 0x01
+		if _, err = NewPublicKey(c, raw); err == nil {
+			t.FailNow()
+		}
+	}
+	f(CurveIdtc26gost341012256paramSetB())
+	f(CurveIdtc26gost341012512paramSetA())
+}
+
+// Run it with -race to check that concurrent use of the same key and
+// curve does not modify them.
+func TestConcurrentVerify(t *testing.T) {
+	c := CurveIdtc26gost34102012256paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, _ := prv.PublicKey()
+	digest := make([]byte, 32)
+	rand.Read(digest)
+	sig, err := prv.SignDigest(digest, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const goroutines = 16
+	errs := make(chan string, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 4; j++ {
+				if valid, err := pub.VerifyDigest(digest, sig); err != nil || !valid {
+					errs <- "invalid signature"
+					return
+				}
+				if _, err := prv.SignDigest(digest, rand.Reader); err != nil {
+					errs <- err.Error()
+					return
+				}
+				u, v := XY2UV(c, pub.X, pub.Y)
+				if x, y := UV2XY(c, u, v); x.Cmp(pub.X) != 0 || y.Cmp(pub.Y) != 0 {
+					errs <- "conversion mismatch"
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+// Find a point of small order, dividing the cofactor.
+func smallOrderPoint(t *testing.T, c *Curve) (x, y *big.Int) {
+	for i := int64(1); i < 1000; i++ {
+		x = big.NewInt(i)
+		rhs := big.NewInt(0).Mul(x, x)
+		rhs.Add(rhs, c.A)
+		rhs.Mul(rhs, x)
+		rhs.Add(rhs, c.B)
+		rhs.Mod(rhs, c.P)
+		y = big.NewInt(0).ModSqrt(rhs, c.P)
+		if y == nil {
+			continue
+		}
+		if x, y, err := c.Exp(c.Q, x, y); err == nil {
+			return x, y
+		}
+	}
+	t.Fatal("no small order point found")
+	return
+}
+
+func TestPublicKeySubgroup(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	if c.Co.Cmp(bigInt4) != 0 {
+		t.FailNow()
+	}
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NewPublicKey(c, pub.Raw()); err != nil {
+		t.Fatal(err)
+	}
+	tx, ty := smallOrderPoint(t, c)
+	small := &PublicKey{c, tx, ty}
+	if _, err = NewPublicKey(c, small.Raw()); err == nil {
+		t.Fatal("small order point accepted")
+	}
+	bx, by, err := c.Add(pub.X, pub.Y, tx, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad := &PublicKey{c, bx, by}
+	if _, err = NewPublicKey(c, bad.Raw()); err == nil {
+		t.Fatal("point outside the subgroup accepted")
+	}
+	if _, err = NewPublicKeyBigEndian(
+		c, pad(bx.Bytes(), 32), pad(by.Bytes(), 32),
+	); err == nil {
+		t.Fatal("point outside the subgroup accepted")
+	}
+	digest := make([]byte, 32)
+	rand.Read(digest)
+	sign, err := prv.SignDigest(digest, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid, err := pub.VerifyDigest(digest, sign); err != nil || !valid {
+		t.Fatal("valid signature rejected")
+	}
+	if _, err = bad.VerifyDigest(digest, sign); err == nil {
+		t.Fatal("point outside the subgroup accepted by VerifyDigest")
+	}
+	if _, _, err = BatchVerify(bad, []BatchItem{{digest, sign}}); err == nil {
+		t.Fatal("point outside the subgroup accepted by BatchVerify")
+	}
+}
+
+func TestPublicKeyKeyID(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	pub2, err := NewPublicKey(CurveIdtc26gost341012256paramSetA(), pub.Raw())
+	if err != nil {
+		t.FailNow()
+	}
+	id := pub.KeyID()
+	if len(id) != 32 || bytes.Compare(id, pub2.KeyID()) != 0 {
+		t.FailNow()
+	}
+	if pub.String() != hex.EncodeToString(id) || pub.String() != pub2.String() {
+		t.FailNow()
+	}
+	der, err := MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.FailNow()
+	}
+	var info spki
+	if _, err = asn1.Unmarshal(der, &info); err != nil {
+		t.FailNow()
+	}
+	h := gost34112012256.New()
+	h.Write(info.PublicKey.Bytes)
+	if bytes.Compare(h.Sum(nil), id) != 0 {
+		t.FailNow()
+	}
+	prv, err = GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	other, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(other.KeyID(), id) == 0 {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,261 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+)
+
+type signatureDER struct {
+	R *big.Int
+	S *big.Int
+}
+
+// Convert native s||r signature (as SignDigest produces) to DER encoded
+// SEQUENCE { r INTEGER, s INTEGER } structure.
+func MarshalSignatureDER(sig []byte) ([]byte, error) {
+	if len(sig) != 2*32 && len(sig) != 2*64 {
+		return nil, ErrInvalidSignatureLength
+	}
+	pointSize := len(sig) / 2
+	return asn1.Marshal(signatureDER{
+		R: bytes2big(sig[pointSize:]),
+		S: bytes2big(sig[:pointSize]),
+	})
+}
+
+// Parse DER encoded signature strictly: encoding/asn1 ignores trailing
+// SEQUENCE elements, so the result must encode back to the same bytes.
+func parseSignatureDER(der []byte) (*signatureDER, error) {
+	var sig signatureDER
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errorf(ErrTrailingData, "gogost/gost3410: trailing data after signature")
+	}
+	if encoded, err := asn1.Marshal(sig); err != nil || !bytes.Equal(encoded, der) {
+		return nil, errors.New("gogost/gost3410: non-canonical DER signature")
+	}
+	return &sig, nil
+}
+
+// Convert DER encoded SEQUENCE { r INTEGER, s INTEGER } structure to
+// native s||r signature. Point size is determined by the largest of
+// r and s values.
+func UnmarshalSignatureDER(der []byte) ([]byte, error) {
+	sig, err := parseSignatureDER(der)
+	if err != nil {
+		return nil, err
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return nil, errors.New("gogost/gost3410: non-positive signature value")
+	}
+	if sig.R.BitLen() > 512 || sig.S.BitLen() > 512 {
+		return nil, errors.New("gogost/gost3410: too big signature value")
+	}
+	pointSize := PointSize(sig.R)
+	if sig.S.BitLen() > sig.R.BitLen() {
+		pointSize = PointSize(sig.S)
+	}
+	return append(
+		pad(sig.S.Bytes(), pointSize),
+		pad(sig.R.Bytes(), pointSize)...,
+	), nil
+}
+
+// Sign digest with SignDigest and return DER encoded
+// SEQUENCE { r INTEGER, s INTEGER } signature, like ecdsa.SignASN1 does.
+func SignASN1(rand io.Reader, prv *PrivateKey, digest []byte) ([]byte, error) {
+	sig, err := prv.SignDigest(digest, rand)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalSignatureDER(sig)
+}
+
+// Verify DER encoded SEQUENCE { r INTEGER, s INTEGER } signature with
+// VerifyDigest, like ecdsa.VerifyASN1 does. Malformed signature is
+// just invalid.
+func VerifyASN1(pub *PublicKey, digest, sig []byte) bool {
+	parsed, err := parseSignatureDER(sig)
+	if err != nil {
+		return false
+	}
+	return pub.Verify(digest, &Signature{R: parsed.R, S: parsed.S})
+}
+
+// Is the native s||r signature canonical for the curve: it is exactly
+// 2*PointSize bytes long and both r and s are in [1, Q-1] range.
+// VerifyDigest rejects non-canonical signatures and SignDigest never
+// produces them.
+//
+// Unlike ECDSA, GOST R 34.10 signatures are not malleable in s, so
+// there is no low-s normalization. ECDSA verifies with
+// C = (e/s)P + (r/s)Q, where negating s negates C and keeps its X
+// coordinate. GOST R 34.10 verifies with C = (s/e)P - (r/e)Q: negating s
+// negates only the first summand, so (r, Q-s) gives an unrelated point
+// and does not verify, except by negligible chance.
+func IsSignatureCanonical(c *Curve, sig []byte) bool {
+	pointSize := c.PointSize()
+	if len(sig) != 2*pointSize {
+		return false
+	}
+	s := bytes2big(sig[:pointSize])
+	r := bytes2big(sig[pointSize:])
+	return r.Sign() > 0 && r.Cmp(c.Q) < 0 && s.Sign() > 0 && s.Cmp(c.Q) < 0
+}
+
+// Signature as r and s values.
+type Signature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// Parse native s||r signature made with the curve's key.
+func NewSignature(c *Curve, sig []byte) (*Signature, error) {
+	pointSize := c.PointSize()
+	if len(sig) != 2*pointSize {
+		return nil, ErrInvalidSignatureLength
+	}
+	return &Signature{
+		R: bytes2big(sig[pointSize:]),
+		S: bytes2big(sig[:pointSize]),
+	}, nil
+}
+
+// Check that r and s are non-negative and fit in pointSize bytes.
+func (sig *Signature) check(pointSize int) error {
+	for _, v := range []*big.Int{sig.R, sig.S} {
+		if v == nil || v.Sign() < 0 {
+			return errors.New("gogost/gost3410: nil or negative signature value")
+		}
+		if v.BitLen() > 8*pointSize {
+			return errors.New("gogost/gost3410: too big signature value")
+		}
+	}
+	return nil
+}
+
+// Native s||r representation with the curve's point size, as
+// SignDigest produces. Nil, negative and too big values give an error.
+func (sig *Signature) BytesFor(c *Curve) ([]byte, error) {
+	pointSize := c.PointSize()
+	if err := sig.check(pointSize); err != nil {
+		return nil, err
+	}
+	return append(
+		pad(sig.S.Bytes(), pointSize),
+		pad(sig.R.Bytes(), pointSize)...,
+	), nil
+}
+
+// DER encoded SEQUENCE { r INTEGER, s INTEGER } representation.
+func (sig *Signature) DER() ([]byte, error) {
+	if sig.R == nil || sig.S == nil || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return nil, errors.New("gogost/gost3410: non-positive signature value")
+	}
+	return asn1.Marshal(signatureDER{R: sig.R, S: sig.S})
+}
+
+// Raw signature layout. GoGOST's native one (SignDigest and
+// VerifyDigest) is SignatureSRBigEndian: s value followed by r, each
+// of them is big-endian padded to PointSize bytes. It is used
+// in X.509 certificates and CMS (RFC 4491, RFC 9215), and by
+// OpenSSL's GOST engine. CryptoAPI-style interfaces (CryptoPro CSP's
+// CryptSignHash) return the whole native signature byte-reversed,
+// which is SignatureRSLittleEndian, as PrivateKeyReverseDigestAndSignature
+// produces. Two other layouts are seen in ad hoc formats that put r first
+// or reverse every half in place.
+type SignatureEncoding int
+
+const (
+	// s||r, each half is big-endian.
+	SignatureSRBigEndian SignatureEncoding = iota
+
+	// r||s, each half is big-endian.
+	SignatureRSBigEndian
+
+	// s||r, each half is little-endian.
+	SignatureSRLittleEndian
+
+	// r||s, each half is little-endian: byte-reversed native layout.
+	SignatureRSLittleEndian
+)
+
+func (enc SignatureEncoding) valid() bool {
+	return enc >= SignatureSRBigEndian && enc <= SignatureRSLittleEndian
+}
+
+func (enc SignatureEncoding) rFirst() bool {
+	return enc == SignatureRSBigEndian || enc == SignatureRSLittleEndian
+}
+
+func (enc SignatureEncoding) littleEndian() bool {
+	return enc == SignatureSRLittleEndian || enc == SignatureRSLittleEndian
+}
+
+// Encode the signature with the curve's point size in given layout.
+// Nil, negative and too big values give an error.
+func (sig *Signature) Encode(c *Curve, enc SignatureEncoding) ([]byte, error) {
+	if !enc.valid() {
+		return nil, errors.New("gogost/gost3410: unknown signature encoding")
+	}
+	pointSize := c.PointSize()
+	if err := sig.check(pointSize); err != nil {
+		return nil, err
+	}
+	first, second := sig.S, sig.R
+	if enc.rFirst() {
+		first, second = second, first
+	}
+	a := pad(first.Bytes(), pointSize)
+	b := pad(second.Bytes(), pointSize)
+	if enc.littleEndian() {
+		reverse(a)
+		reverse(b)
+	}
+	return append(a, b...), nil
+}
+
+// Decode the signature for the curve in given layout. Values are not
+// checked to be in [1, Q-1] range, VerifyDigest does that.
+func DecodeSignature(c *Curve, raw []byte, enc SignatureEncoding) (*Signature, error) {
+	if !enc.valid() {
+		return nil, errors.New("gogost/gost3410: unknown signature encoding")
+	}
+	pointSize := c.PointSize()
+	if len(raw) != 2*pointSize {
+		return nil, ErrInvalidSignatureLength
+	}
+	a := make([]byte, pointSize)
+	b := make([]byte, pointSize)
+	copy(a, raw[:pointSize])
+	copy(b, raw[pointSize:])
+	if enc.littleEndian() {
+		reverse(a)
+		reverse(b)
+	}
+	if enc.rFirst() {
+		a, b = b, a
+	}
+	return &Signature{R: bytes2big(b), S: bytes2big(a)}, nil
+}
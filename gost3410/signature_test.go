@@ -0,0 +1,384 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestSignatureDER(t *testing.T) {
+	f := func(c *Curve) {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		digest := make([]byte, c.PointSize())
+		rand.Read(digest)
+		sign, err := prv.SignDigest(digest, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		der, err := MarshalSignatureDER(sign)
+		if err != nil {
+			t.FailNow()
+		}
+		var sig signatureDER
+		if _, err = asn1.Unmarshal(der, &sig); err != nil {
+			t.FailNow()
+		}
+		if bytes.Compare(pad(sig.S.Bytes(), c.PointSize()), sign[:c.PointSize()]) != 0 {
+			t.FailNow()
+		}
+		if bytes.Compare(pad(sig.R.Bytes(), c.PointSize()), sign[c.PointSize():]) != 0 {
+			t.FailNow()
+		}
+		got, err := UnmarshalSignatureDER(der)
+		if err != nil {
+			t.FailNow()
+		}
+		if bytes.Compare(got, sign) != 0 {
+			t.FailNow()
+		}
+	}
+	f(CurveIdGostR34102001TestParamSet())
+	f(CurveIdtc26gost341012512paramSetA())
+}
+
+func TestSignatureDERRandom(t *testing.T) {
+	f := func(s, r [32]byte) bool {
+		s[0] |= 0x01
+		r[0] |= 0x01
+		sign := append(s[:], r[:]...)
+		der, err := MarshalSignatureDER(sign)
+		if err != nil {
+			return false
+		}
+		got, err := UnmarshalSignatureDER(der)
+		if err != nil {
+			return false
+		}
+		return bytes.Compare(got, sign) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSignatureDERMalformed(t *testing.T) {
+	if _, err := MarshalSignatureDER(make([]byte, 63)); err == nil {
+		t.FailNow()
+	}
+	der, err := asn1.Marshal(signatureDER{bigInt1, zero})
+	if err != nil {
+		t.FailNow()
+	}
+	if _, err = UnmarshalSignatureDER(der); err == nil {
+		t.FailNow()
+	}
+	der, err = asn1.Marshal(signatureDER{bigInt1, bigInt2})
+	if err != nil {
+		t.FailNow()
+	}
+	if _, err = UnmarshalSignatureDER(append(der, 0x00)); err == nil {
+		t.FailNow()
+	}
+	if _, err = UnmarshalSignatureDER(der[:len(der)-1]); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestSignatureMalleability(t *testing.T) {
+	c := CurveIdGostR34102001TestParamSet()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, _ := prv.PublicKey()
+	digest := make([]byte, 32)
+	rand.Read(digest)
+	sig, err := prv.SignDigest(digest, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsSignatureCanonical(c, sig) {
+		t.FailNow()
+	}
+	s := bytes2big(sig[:32])
+	r := sig[32:]
+
+	// (r, Q-s) is canonical, but unlike ECDSA does not verify
+	negated := append(pad(big.NewInt(0).Sub(c.Q, s).Bytes(), 32), r...)
+	if !IsSignatureCanonical(c, negated) {
+		t.FailNow()
+	}
+	if valid, _ := pub.VerifyDigest(digest, negated); valid {
+		t.FailNow()
+	}
+
+	// (r, s+Q) fits in the same length for that curve, but is rejected
+	shifted := append(pad(big.NewInt(0).Add(s, c.Q).Bytes(), 32), r...)
+	if IsSignatureCanonical(c, shifted) {
+		t.FailNow()
+	}
+	if valid, _ := pub.VerifyDigest(digest, shifted); valid {
+		t.FailNow()
+	}
+
+	if IsSignatureCanonical(c, sig[1:]) {
+		t.FailNow()
+	}
+	if IsSignatureCanonical(c, make([]byte, 64)) {
+		t.FailNow()
+	}
+}
+
+func TestSignatureStruct(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetA(),
+	} {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, _ := prv.PublicKey()
+		digest := make([]byte, c.PointSize())
+		rand.Read(digest)
+		sig, err := prv.SignDigestSig(digest, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pub.Verify(digest, sig) {
+			t.FailNow()
+		}
+		raw, err := sig.BytesFor(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid, err := pub.VerifyDigest(digest, raw)
+		if err != nil || !valid {
+			t.FailNow()
+		}
+		small := &Signature{R: big.NewInt(1), S: big.NewInt(2)}
+		if smallRaw, err := small.BytesFor(c); err != nil || len(smallRaw) != 2*c.PointSize() {
+			t.FailNow()
+		}
+		for _, bad := range []*Signature{
+			{R: nil, S: sig.S},
+			{R: sig.R, S: nil},
+			{R: big.NewInt(-1), S: sig.S},
+			{R: sig.R, S: big.NewInt(0).Lsh(bigInt1, uint(8*c.PointSize()))},
+		} {
+			if _, err = bad.BytesFor(c); err == nil {
+				t.FailNow()
+			}
+			if pub.Verify(digest, bad) {
+				t.FailNow()
+			}
+		}
+		if _, err = (&Signature{}).DER(); err == nil {
+			t.FailNow()
+		}
+		parsed, err := NewSignature(c, raw)
+		if err != nil || parsed.R.Cmp(sig.R) != 0 || parsed.S.Cmp(sig.S) != 0 {
+			t.FailNow()
+		}
+		der, err := sig.DER()
+		if err != nil {
+			t.Fatal(err)
+		}
+		derOld, _ := MarshalSignatureDER(raw)
+		if bytes.Compare(der, derOld) != 0 {
+			t.FailNow()
+		}
+		fromDER, err := UnmarshalSignatureDER(der)
+		if err != nil || bytes.Compare(fromDER, raw) != 0 {
+			t.FailNow()
+		}
+		bad := &Signature{R: sig.R, S: big.NewInt(0).Add(sig.S, c.Q)}
+		if pub.Verify(digest, bad) {
+			t.FailNow()
+		}
+		digest[0] ^= 1
+		if pub.Verify(digest, sig) {
+			t.FailNow()
+		}
+	}
+	if _, err := NewSignature(CurveIdtc26gost341012256paramSetA(), make([]byte, 63)); err == nil {
+		t.FailNow()
+	}
+	if _, err := (&Signature{R: big.NewInt(0), S: big.NewInt(1)}).DER(); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestSignatureEncoding(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	sig := &Signature{R: big.NewInt(0x0102), S: big.NewInt(0x0304)}
+	expected := map[SignatureEncoding][]byte{
+		SignatureSRBigEndian:    append(pad([]byte{3, 4}, 32), pad([]byte{1, 2}, 32)...),
+		SignatureRSBigEndian:    append(pad([]byte{1, 2}, 32), pad([]byte{3, 4}, 32)...),
+		SignatureSRLittleEndian: make([]byte, 64),
+		SignatureRSLittleEndian: make([]byte, 64),
+	}
+	copy(expected[SignatureSRLittleEndian], []byte{4, 3})
+	copy(expected[SignatureSRLittleEndian][32:], []byte{2, 1})
+	copy(expected[SignatureRSLittleEndian], []byte{2, 1})
+	copy(expected[SignatureRSLittleEndian][32:], []byte{4, 3})
+	for enc, raw := range expected {
+		encoded, err := sig.Encode(c, enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Compare(encoded, raw) != 0 {
+			t.Fatal("encoding differs", enc)
+		}
+		decoded, err := DecodeSignature(c, raw, enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decoded.R.Cmp(sig.R) != 0 || decoded.S.Cmp(sig.S) != 0 {
+			t.Fatal("decoding differs", enc)
+		}
+	}
+	native, err := sig.BytesFor(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(native, expected[SignatureSRBigEndian]) != 0 {
+		t.FailNow()
+	}
+	reversed := append([]byte{}, native...)
+	reverse(reversed)
+	if bytes.Compare(reversed, expected[SignatureRSLittleEndian]) != 0 {
+		t.FailNow()
+	}
+	if _, err := sig.Encode(c, SignatureEncoding(4)); err == nil {
+		t.Fatal("unknown encoding accepted")
+	}
+	if _, err := DecodeSignature(c, native[1:], SignatureSRBigEndian); err == nil {
+		t.Fatal("short signature accepted")
+	}
+	huge := &Signature{R: big.NewInt(0).Lsh(bigInt1, 256), S: bigInt1}
+	if _, err := huge.Encode(c, SignatureSRBigEndian); err == nil {
+		t.Fatal("too big value accepted")
+	}
+}
+
+func TestSignatureEncodingInvalid(t *testing.T) {
+	c := CurveIdtc26gost341012512paramSetA()
+	oversized := big.NewInt(0).Lsh(bigInt1, 512)
+	for _, sig := range []*Signature{
+		{R: oversized, S: bigInt1},
+		{R: bigInt1, S: oversized},
+		{R: nil, S: bigInt1},
+		{R: bigInt1, S: nil},
+		{R: big.NewInt(-1), S: bigInt1},
+	} {
+		for _, enc := range []SignatureEncoding{
+			SignatureSRBigEndian,
+			SignatureRSLittleEndian,
+		} {
+			if _, err := sig.Encode(c, enc); err == nil {
+				t.Fatal("invalid signature is encoded")
+			}
+		}
+	}
+	max := big.NewInt(0).Sub(oversized, bigInt1)
+	raw, err := (&Signature{R: max, S: max}).Encode(c, SignatureSRBigEndian)
+	if err != nil || len(raw) != 128 {
+		t.FailNow()
+	}
+}
+
+func TestSignatureEncodingVerify(t *testing.T) {
+	c := CurveIdtc26gost341012512paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := make([]byte, 64)
+	rand.Read(digest)
+	sig, err := prv.SignDigestSig(digest, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for enc := SignatureSRBigEndian; enc <= SignatureRSLittleEndian; enc++ {
+		raw, err := sig.Encode(c, enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := DecodeSignature(c, raw, enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pub.Verify(digest, decoded) {
+			t.Fatal("decoded signature does not verify", enc)
+		}
+	}
+}
+
+func TestSignVerifyASN1(t *testing.T) {
+	f := func(c *Curve) {
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		digest := make([]byte, c.PointSize())
+		rand.Read(digest)
+		sig, err := SignASN1(rand.Reader, prv, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !VerifyASN1(pub, digest, sig) {
+			t.Fatal("signature does not verify")
+		}
+		digest[0] ^= 1
+		if VerifyASN1(pub, digest, sig) {
+			t.Fatal("signature of other digest verifies")
+		}
+		digest[0] ^= 1
+		for _, malformed := range [][]byte{
+			nil,
+			{},
+			{0x30},
+			sig[:len(sig)-1],
+			append(append([]byte{}, sig...), 0x00),
+			{0x30, 0x06, 0x02, 0x01, 0x00, 0x02, 0x01, 0x01},
+			{0x30, 0x06, 0x02, 0x01, 0xFF, 0x02, 0x01, 0x01},
+			{0x04, 0x02, 0x00, 0x00},
+		} {
+			if VerifyASN1(pub, digest, malformed) {
+				t.Fatal("malformed signature verifies")
+			}
+		}
+	}
+	f(CurveIdtc26gost341012256paramSetB())
+	f(CurveIdtc26gost341012512paramSetA())
+}
@@ -0,0 +1,133 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"container/list"
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+)
+
+// Key-dependent values computed once for many signatures.
+type signPre struct {
+	table baseTable // nil if field arithmetic is unavailable
+	key   fe        // private key in Montgomery form modulo Q
+}
+
+// Signer for many digests with the same private key. It takes the
+// curve's base point table and converts the key to the internal scalar
+// form once, instead of on each SignDigest call. Signatures are the
+// same as SignDigest makes. Signer is safe for concurrent use. Key and
+// curve must not be changed after NewSigner.
+type Signer struct {
+	prv   *PrivateKey
+	pre   *signPre
+	guard *rGuard
+}
+
+// Recently made signatures r values. The same r for the same key means
+// the same k (or -k), that reveals the private key with two signatures,
+// unless it is the same signature of the same e made again.
+type rGuard struct {
+	capacity int
+	m        sync.Mutex
+	seen     map[string]*list.Element
+	order    *list.List
+}
+
+// Remembered signature of e.
+type rGuardEntry struct {
+	r  string
+	es string // e||s
+}
+
+// Remember r with e and s, returning ErrKReuse if r is already known
+// with different e or s.
+func (g *rGuard) remember(r, e, s []byte) error {
+	g.m.Lock()
+	defer g.m.Unlock()
+	key := string(r)
+	es := string(e) + string(s)
+	if known, ok := g.seen[key]; ok {
+		if known.Value.(*rGuardEntry).es != es {
+			return ErrKReuse
+		}
+		g.order.MoveToBack(known)
+		return nil
+	}
+	g.seen[key] = g.order.PushBack(&rGuardEntry{key, es})
+	if g.order.Len() > g.capacity {
+		oldest := g.order.Front()
+		g.order.Remove(oldest)
+		delete(g.seen, oldest.Value.(*rGuardEntry).r)
+	}
+	return nil
+}
+
+// Create Signer for prv. If the internal scalar form can not be used
+// with prv's curve or key, Signer just calls SignDigest.
+func NewSigner(prv *PrivateKey) *Signer {
+	c := prv.C
+	if c.fq == nil || c.fq.pBig != c.Q || prv.Key.Sign() <= 0 || prv.Key.Cmp(c.Q) >= 0 {
+		return &Signer{prv: prv}
+	}
+	pre := signPre{key: c.fq.fromReduced(prv.Key)}
+	if c.fp != nil && c.fp.pBig == c.P {
+		pre.table = c.baseTable()
+	}
+	return &Signer{prv: prv, pre: &pre}
+}
+
+// Create Signer, that remembers r values of up to capacity last
+// signatures and refuses to return the signature with ErrKReuse if r is
+// repeated: that happens only if random number generator is broken and
+// the same k is used again, that leaks the private key. The same
+// signature of the same digest made again, as deterministic or fixed
+// readers give, is not an error: it leaks nothing new. It is
+// a development aid for catching such failures, not a protection: only
+// reuse within the same Signer in the current process is detected and
+// older values are forgotten.
+func NewSignerWithReuseGuard(prv *PrivateKey, capacity int) (*Signer, error) {
+	if capacity < 1 {
+		return nil, errors.New("gogost/gost3410: invalid reuse guard capacity")
+	}
+	s := NewSigner(prv)
+	s.guard = &rGuard{
+		capacity: capacity,
+		seen:     make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+	return s, nil
+}
+
+// Sign the digest, like SignDigest does.
+func (s *Signer) Sign(digest []byte, rand io.Reader) ([]byte, error) {
+	sign, err := s.prv.signDigestPre(digest, rand, true, s.pre)
+	if err != nil || s.guard == nil {
+		return sign, err
+	}
+	var e big.Int
+	setDigestE(&e, digest, s.prv.C.Q)
+	pointSize := len(sign) / 2
+	if err = s.guard.remember(
+		sign[pointSize:], pad(e.Bytes(), pointSize), sign[:pointSize],
+	); err != nil {
+		return nil, err
+	}
+	return sign, nil
+}
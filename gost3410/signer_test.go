@@ -0,0 +1,151 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestSigner(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		signer := NewSigner(prv)
+		digest := make([]byte, c.PointSize())
+		rnd := make([]byte, c.PointSize())
+		for i := 0; i < 4; i++ {
+			rand.Read(digest)
+			rand.Read(rnd)
+			sign, err := signer.Sign(digest, bytes.NewReader(rnd))
+			if err != nil {
+				t.Fatal(c.Name, err)
+			}
+			ref, err := prv.SignDigest(digest, bytes.NewReader(rnd))
+			if err != nil {
+				t.FailNow()
+			}
+			if bytes.Compare(sign, ref) != 0 {
+				t.Fatal(c.Name, "differs from SignDigest")
+			}
+			valid, err := pub.VerifyDigest(digest, sign)
+			if err != nil || !valid {
+				t.Fatal(c.Name, "invalid")
+			}
+		}
+	}
+}
+
+func TestSignerZeroKey(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	signer := NewSigner(&PrivateKey{c, zero})
+	if _, err := signer.Sign(make([]byte, 32), rand.Reader); err != ErrZeroPrivateKey {
+		t.FailNow()
+	}
+}
+
+type constReader byte
+
+func (r constReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+func TestSignerReuseGuard(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	if _, err = NewSignerWithReuseGuard(prv, 0); err == nil {
+		t.FailNow()
+	}
+	signer, err := NewSignerWithReuseGuard(prv, 2)
+	if err != nil {
+		t.FailNow()
+	}
+	digest := make([]byte, c.PointSize())
+	for i := 0; i < 3; i++ {
+		rand.Read(digest)
+		if _, err = signer.Sign(digest, rand.Reader); err != nil {
+			t.FailNow()
+		}
+	}
+	broken := constReader(0x42)
+	sign, err := signer.Sign(digest, broken)
+	if err != nil {
+		t.FailNow()
+	}
+	again, err := signer.Sign(digest, broken)
+	if err != nil || bytes.Compare(again, sign) != 0 {
+		t.Fatal("the same signature of the same digest is refused", err)
+	}
+	k := bytes2big(bytes.Repeat([]byte{0x42}, c.PointSize()))
+	k.Mod(k, c.Q)
+	negK := bytes.NewReader(pad(k.Sub(c.Q, k).Bytes(), c.PointSize()))
+	if _, err = signer.Sign(digest, negK); err != ErrKReuse {
+		t.Fatal("-k reuse for the same digest is not detected", err)
+	}
+	rand.Read(digest)
+	if _, err = signer.Sign(digest, broken); err != ErrKReuse {
+		t.Fatal(err)
+	}
+	if _, err = NewSigner(prv).Sign(digest, broken); err != nil {
+		t.FailNow()
+	}
+}
+
+func benchmarkSign10k(b *testing.B, viaSigner bool) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		b.FailNow()
+	}
+	digest := make([]byte, c.PointSize())
+	rand.Read(digest)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		signer := NewSigner(prv)
+		for j := 0; j < 10000; j++ {
+			if viaSigner {
+				_, err = signer.Sign(digest, rand.Reader)
+			} else {
+				_, err = prv.SignDigest(digest, rand.Reader)
+			}
+			if err != nil {
+				b.FailNow()
+			}
+		}
+	}
+}
+
+func BenchmarkSign10kSigner(b *testing.B) {
+	benchmarkSign10k(b, true)
+}
+
+func BenchmarkSign10kSignDigest(b *testing.B) {
+	benchmarkSign10k(b, false)
+}
@@ -0,0 +1,101 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"hash"
+	"io"
+
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+)
+
+// Hash msg with the hash having curve's point size and reverse the
+// digest, as it is done in X.509 and CMS (RFC 4491) and by
+// PrivateKeyReverseDigest.
+func messageDigest(c *Curve, newHash func() hash.Hash, msg []byte) ([]byte, error) {
+	h := newHash()
+	if h.Size() != DigestSizeForCurve(c) {
+		return nil, ErrDigestSizeMismatch
+	}
+	if _, err := h.Write(msg); err != nil {
+		return nil, err
+	}
+	digest := h.Sum(nil)
+	reverse(digest)
+	return digest, nil
+}
+
+// Sign msg hashed with 256-bit Streebog. Only for 256-bit curves.
+// Digest is reversed before signing, like PrivateKeyReverseDigest does.
+func (prv *PrivateKey) SignStreebog256(msg []byte, rand io.Reader) ([]byte, error) {
+	digest, err := messageDigest(prv.C, gost34112012256.New, msg)
+	if err != nil {
+		return nil, err
+	}
+	return prv.SignDigest(digest, rand)
+}
+
+// Sign msg hashed with 512-bit Streebog. Only for 512-bit curves.
+// Digest is reversed before signing, like PrivateKeyReverseDigest does.
+func (prv *PrivateKey) SignStreebog512(msg []byte, rand io.Reader) ([]byte, error) {
+	digest, err := messageDigest(prv.C, gost34112012512.New, msg)
+	if err != nil {
+		return nil, err
+	}
+	return prv.SignDigest(digest, rand)
+}
+
+// Verify signature made with SignStreebog256.
+func (pub *PublicKey) VerifyStreebog256(msg, signature []byte) (bool, error) {
+	digest, err := messageDigest(pub.C, gost34112012256.New, msg)
+	if err != nil {
+		return false, err
+	}
+	return pub.VerifyDigest(digest, signature)
+}
+
+// Verify signature made with SignStreebog512.
+func (pub *PublicKey) VerifyStreebog512(msg, signature []byte) (bool, error) {
+	digest, err := messageDigest(pub.C, gost34112012512.New, msg)
+	if err != nil {
+		return false, err
+	}
+	return pub.VerifyDigest(digest, signature)
+}
+
+// Sign msg hashed with any hash function, for example GOST R 34.11-94
+// on legacy systems. Hash's digest size must be equal to
+// DigestSizeForCurve: 32 bytes for 256-bit curves, 64 for 512-bit ones,
+// error is returned otherwise. Digest is reversed before signing, as
+// GOST expects little-endian one, and SignDigest reduces it modulo Q.
+// SignStreebog256 is the same as SignMessage with gost34112012256.New.
+func (prv *PrivateKey) SignMessage(msg []byte, newHash func() hash.Hash, rand io.Reader) ([]byte, error) {
+	digest, err := messageDigest(prv.C, newHash, msg)
+	if err != nil {
+		return nil, err
+	}
+	return prv.SignDigest(digest, rand)
+}
+
+// Verify signature made with SignMessage with the same hash function.
+func (pub *PublicKey) VerifyMessage(msg []byte, newHash func() hash.Hash, signature []byte) (bool, error) {
+	digest, err := messageDigest(pub.C, newHash, msg)
+	if err != nil {
+		return false, err
+	}
+	return pub.VerifyDigest(digest, signature)
+}
@@ -0,0 +1,152 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/rand"
+	"hash"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost28147"
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+	"go.cypherpunks.ru/gogost/v5/gost341194"
+)
+
+func TestSignStreebog(t *testing.T) {
+	msg := []byte("data to be signed")
+
+	t.Run("256", func(t *testing.T) {
+		prv, err := GenPrivateKey(CurveIdtc26gost341012256paramSetB(), rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		sign, err := prv.SignStreebog256(msg, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		valid, err := pub.VerifyStreebog256(msg, sign)
+		if err != nil || !valid {
+			t.FailNow()
+		}
+		valid, err = pub.VerifyStreebog256(msg[1:], sign)
+		if err != nil || valid {
+			t.FailNow()
+		}
+		// The same as signing with PrivateKeyReverseDigest
+		h := gost34112012256.New()
+		h.Write(msg)
+		sign, err = (&PrivateKeyReverseDigest{prv}).Sign(rand.Reader, h.Sum(nil), nil)
+		if err != nil {
+			t.FailNow()
+		}
+		valid, err = pub.VerifyStreebog256(msg, sign)
+		if err != nil || !valid {
+			t.FailNow()
+		}
+		if _, err = prv.SignStreebog512(msg, rand.Reader); err == nil {
+			t.FailNow()
+		}
+		if _, err = pub.VerifyStreebog512(msg, sign); err == nil {
+			t.FailNow()
+		}
+	})
+
+	t.Run("512", func(t *testing.T) {
+		prv, err := GenPrivateKey(CurveIdtc26gost341012512paramSetA(), rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		sign, err := prv.SignStreebog512(msg, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		valid, err := pub.VerifyStreebog512(msg, sign)
+		if err != nil || !valid {
+			t.FailNow()
+		}
+		h := gost34112012512.New()
+		h.Write(msg)
+		sign, err = (&PrivateKeyReverseDigest{prv}).Sign(rand.Reader, h.Sum(nil), nil)
+		if err != nil {
+			t.FailNow()
+		}
+		valid, err = pub.VerifyStreebog512(msg, sign)
+		if err != nil || !valid {
+			t.FailNow()
+		}
+		if _, err = prv.SignStreebog256(msg, rand.Reader); err == nil {
+			t.FailNow()
+		}
+	})
+}
+
+func TestSignMessage(t *testing.T) {
+	msg := []byte("data to be signed")
+	gost94 := func() hash.Hash {
+		return gost341194.New(&gost28147.SboxIdGostR341194CryptoProParamSet)
+	}
+	for _, tc := range []struct {
+		c       *Curve
+		newHash func() hash.Hash
+	}{
+		{CurveIdGostR34102001CryptoProAParamSet(), gost94},
+		{CurveIdtc26gost341012256paramSetB(), gost34112012256.New},
+		{CurveIdtc26gost341012512paramSetA(), gost34112012512.New},
+	} {
+		prv, err := GenPrivateKey(tc.c, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sign, err := prv.SignMessage(msg, tc.newHash, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid, err := pub.VerifyMessage(msg, tc.newHash, sign)
+		if err != nil || !valid {
+			t.Fatal("signature does not verify")
+		}
+		h := tc.newHash()
+		h.Write(msg)
+		digest := h.Sum(nil)
+		reverse(digest)
+		if valid, err = pub.VerifyDigest(digest, sign); err != nil || !valid {
+			t.Fatal("digest is not reversed")
+		}
+		if valid, _ = pub.VerifyMessage(append(msg, 0), tc.newHash, sign); valid {
+			t.Fatal("other message verifies")
+		}
+	}
+	prv, err := GenPrivateKey(CurveIdtc26gost341012512paramSetA(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = prv.SignMessage(msg, gost94, rand.Reader); err == nil {
+		t.Fatal("digest size mismatch accepted")
+	}
+}
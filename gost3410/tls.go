@@ -0,0 +1,53 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+// TLS 1.3 SignatureScheme values (RFC 9367), compatible with
+// crypto/tls.SignatureScheme.
+const (
+	TLSSignatureScheme256A uint16 = 0x0709
+	TLSSignatureScheme256B uint16 = 0x070A
+	TLSSignatureScheme256C uint16 = 0x070B
+	TLSSignatureScheme256D uint16 = 0x070C
+	TLSSignatureScheme512A uint16 = 0x070D
+	TLSSignatureScheme512B uint16 = 0x070E
+	TLSSignatureScheme512C uint16 = 0x070F
+)
+
+var tlsSignatureSchemes = []struct {
+	curve  func() *Curve
+	scheme uint16
+}{
+	{CurveIdtc26gost341012256paramSetA, TLSSignatureScheme256A},
+	{CurveIdtc26gost341012256paramSetB, TLSSignatureScheme256B},
+	{CurveIdtc26gost341012256paramSetC, TLSSignatureScheme256C},
+	{CurveIdtc26gost341012256paramSetD, TLSSignatureScheme256D},
+	{CurveIdtc26gost341012512paramSetA, TLSSignatureScheme512A},
+	{CurveIdtc26gost341012512paramSetB, TLSSignatureScheme512B},
+	{CurveIdtc26gost341012512paramSetC, TLSSignatureScheme512C},
+}
+
+// Get TLS SignatureScheme for the key's curve. Curves are compared with
+// Curve.Equal, so CryptoPro aliases have the scheme too. Test curves
+// have none. Signed data is hashed with SignatureAlgorithm's hash.
+func TLSSignatureScheme(pub *PublicKey) (uint16, bool) {
+	for _, s := range tlsSignatureSchemes {
+		if pub.C.Equal(s.curve()) {
+			return s.scheme, true
+		}
+	}
+	return 0, false
+}
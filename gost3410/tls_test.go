@@ -0,0 +1,71 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestSignatureAlgorithm(t *testing.T) {
+	expected := map[string]uint16{
+		"id-tc26-gost-3410-12-256-paramSetA":        TLSSignatureScheme256A,
+		"id-tc26-gost-3410-12-256-paramSetB":        TLSSignatureScheme256B,
+		"id-tc26-gost-3410-12-256-paramSetC":        TLSSignatureScheme256C,
+		"id-tc26-gost-3410-12-256-paramSetD":        TLSSignatureScheme256D,
+		"id-tc26-gost-3410-12-512-paramSetA":        TLSSignatureScheme512A,
+		"id-tc26-gost-3410-12-512-paramSetB":        TLSSignatureScheme512B,
+		"id-tc26-gost-3410-12-512-paramSetC":        TLSSignatureScheme512C,
+		"id-GostR3410-2001-CryptoPro-A-ParamSet":    TLSSignatureScheme256B,
+		"id-GostR3410-2001-CryptoPro-B-ParamSet":    TLSSignatureScheme256C,
+		"id-GostR3410-2001-CryptoPro-C-ParamSet":    TLSSignatureScheme256D,
+		"id-GostR3410-2001-CryptoPro-XchA-ParamSet": TLSSignatureScheme256B,
+		"id-GostR3410-2001-CryptoPro-XchB-ParamSet": TLSSignatureScheme256D,
+		"id-tc26-gost-3410-2012-256-paramSetA":      TLSSignatureScheme256A,
+		"id-tc26-gost-3410-2012-256-paramSetB":      TLSSignatureScheme256B,
+		"id-tc26-gost-3410-2012-256-paramSetC":      TLSSignatureScheme256C,
+		"id-tc26-gost-3410-2012-256-paramSetD":      TLSSignatureScheme256D,
+		"id-tc26-gost-3410-2012-512-paramSetA":      TLSSignatureScheme512A,
+		"id-tc26-gost-3410-2012-512-paramSetB":      TLSSignatureScheme512B,
+		"id-tc26-gost-3410-2012-512-paramSetC":      TLSSignatureScheme512C,
+	}
+	for _, curve := range curves {
+		c := curve()
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		oid, hashNew := SignatureAlgorithm(pub)
+		if c.PointSize() == 64 {
+			if !oid.Equal(OIDtc26SignWithDigestGost341012512) {
+				t.Fatal(c.Name)
+			}
+		} else if !oid.Equal(OIDtc26SignWithDigestGost341012256) {
+			t.Fatal(c.Name)
+		}
+		if hashNew().Size() != DigestSizeForCurve(c) {
+			t.Fatal(c.Name)
+		}
+		scheme, ok := TLSSignatureScheme(pub)
+		if expectedScheme, known := expected[c.Name]; ok != known || scheme != expectedScheme {
+			t.Fatal(c.Name, scheme)
+		}
+	}
+}
@@ -23,14 +23,57 @@ func bytes2big(d []byte) *big.Int {
 	return big.NewInt(0).SetBytes(d)
 }
 
+// Reverse bytes order in place. Loop depends only on the length.
 func reverse(d []byte) {
 	for i, j := 0, len(d)-1; i < j; i, j = i+1, j-1 {
 		d[i], d[j] = d[j], d[i]
 	}
 }
 
+// Left pad d with zeros to size bytes, returning new buffer. Every
+// output byte is produced by the same masked load from d, without
+// branches on d position or contents. d longer than size is a
+// programming error and leads to panic, as before.
 func pad(d []byte, size int) []byte {
-	return append(make([]byte, size-len(d)), d...)
+	if len(d) > size {
+		panic("gogost/gost3410: value is longer than pad size")
+	}
+	r := make([]byte, size)
+	if len(d) == 0 {
+		return r
+	}
+	off := int64(size - len(d))
+	for i := 0; i < size; i++ {
+		j := int64(i) - off
+		neg := j >> 63
+		r[i] = d[j&^neg] & byte(^neg)
+	}
+	return r
+}
+
+// Interpret b as little-endian integer, the way NewPrivateKey reads
+// raw private key. Empty b gives zero. No range check is made.
+func ScalarFromBytesLE(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	copy(be, b)
+	reverse(be)
+	s := bytes2big(be)
+	for i := range be {
+		be[i] = 0
+	}
+	return s
+}
+
+// Encode non-negative s as size bytes long little-endian integer, zero
+// padded, the way PrivateKey.Raw does (with PointSize as size). It
+// panics if s is negative or does not fit in size bytes.
+func ScalarToBytesLE(s *big.Int, size int) []byte {
+	if s.Sign() < 0 {
+		panic("gogost/gost3410: negative scalar")
+	}
+	raw := pad(s.Bytes(), size)
+	reverse(raw)
+	return raw
 }
 
 func PointSize(p *big.Int) int {
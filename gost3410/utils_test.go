@@ -0,0 +1,120 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestPad(t *testing.T) {
+	for _, tc := range []struct {
+		in   []byte
+		size int
+		out  []byte
+	}{
+		{nil, 4, []byte{0, 0, 0, 0}},
+		{[]byte{1}, 4, []byte{0, 0, 0, 1}},
+		{[]byte{1, 2, 3}, 4, []byte{0, 1, 2, 3}},
+		{[]byte{1, 2, 3, 4}, 4, []byte{1, 2, 3, 4}},
+		{nil, 0, []byte{}},
+	} {
+		got := pad(tc.in, tc.size)
+		if bytes.Compare(got, tc.out) != 0 || len(got) != tc.size {
+			t.Fatal(tc.in, tc.size, got)
+		}
+	}
+	in := []byte{1, 2, 3, 4}
+	if got := pad(in, 4); &got[0] == &in[0] {
+		t.Fatal("pad returned the same buffer")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("over-length input did not panic")
+		}
+	}()
+	pad([]byte{1, 2, 3, 4, 5}, 4)
+}
+
+func TestReverse(t *testing.T) {
+	for _, tc := range [][2][]byte{
+		{{}, {}},
+		{{1}, {1}},
+		{{1, 2}, {2, 1}},
+		{{1, 2, 3}, {3, 2, 1}},
+	} {
+		reverse(tc[0])
+		if bytes.Compare(tc[0], tc[1]) != 0 {
+			t.Fatal(tc[1])
+		}
+	}
+}
+
+func TestScalarBytesLE(t *testing.T) {
+	for _, size := range []int{32, 64} {
+		s := ScalarFromBytesLE(make([]byte, size))
+		if s.Sign() != 0 {
+			t.FailNow()
+		}
+		if bytes.Compare(ScalarToBytesLE(s, size), make([]byte, size)) != 0 {
+			t.FailNow()
+		}
+		max := bytes.Repeat([]byte{0xFF}, size)
+		s = ScalarFromBytesLE(max)
+		if s.BitLen() != 8*size {
+			t.FailNow()
+		}
+		if bytes.Compare(ScalarToBytesLE(s, size), max) != 0 {
+			t.FailNow()
+		}
+		raw := make([]byte, size)
+		raw[0] = 0x01
+		raw[size-1] = 0x80
+		s = ScalarFromBytesLE(raw)
+		if s.Bit(0) != 1 || s.BitLen() != 8*size {
+			t.FailNow()
+		}
+		if bytes.Compare(ScalarToBytesLE(s, size), raw) != 0 {
+			t.FailNow()
+		}
+	}
+	if ScalarFromBytesLE(nil).Sign() != 0 {
+		t.FailNow()
+	}
+	c := CurveIdtc26gost341012512paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(ScalarToBytesLE(prv.Key, c.PointSize()), prv.Raw()) != 0 {
+		t.FailNow()
+	}
+	if ScalarFromBytesLE(prv.Raw()).Cmp(prv.Key) != 0 {
+		t.FailNow()
+	}
+	for _, s := range []*big.Int{big.NewInt(-1), big.NewInt(0).Lsh(bigInt1, 256)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.FailNow()
+				}
+			}()
+			ScalarToBytesLE(s, 32)
+		}()
+	}
+}
@@ -16,21 +16,145 @@
 package gost3410
 
 import (
+	"context"
+	"hash"
 	"math/big"
+
+	"go.cypherpunks.ru/gogost/v5/gost341194"
 )
 
+// Validate peer's public key and compute prv*pub point.
+func (prv *PrivateKey) sharedPoint(pub *PublicKey) (x, y *big.Int, err error) {
+	if !prv.C.Equal(pub.C) {
+		return nil, nil, ErrDifferentCurve
+	}
+	if pub.X == nil || pub.Y == nil {
+		return nil, nil, errorf(ErrPointAtInfinity, "gogost/gost3410: public key is the point at infinity")
+	}
+	if !pub.IsOnCurve() {
+		return nil, nil, errorf(ErrPointNotOnCurve, "gogost/gost3410: public key is not on the curve")
+	}
+	return prv.C.Exp(prv.Key, pub.X, pub.Y)
+}
+
+// Multiply shared point by ukm*cofactor.
+func kekFromShared(c *Curve, keyX, keyY, ukm *big.Int) ([]byte, error) {
+	var err error
+	u := big.NewInt(0).Set(ukm).Mul(ukm, c.Co)
+	if u.Cmp(bigInt1) != 0 {
+		keyX, keyY, err = c.Exp(u, keyX, keyY)
+		if err != nil {
+			return nil, err
+		}
+	}
+	pk := PublicKey{c, keyX, keyY}
+	return pk.Raw(), nil
+}
+
+// Compute raw shared point (ukm*cofactor*prv)*pub, without hashing.
+// Peer's public key must belong to the same curve and lie on it. Result
+// is multiplied by the curve's cofactor, so low-order peer's points give
+// the point at infinity, that is reported as an error.
 func (prv *PrivateKey) KEK(pub *PublicKey, ukm *big.Int) ([]byte, error) {
-	keyX, keyY, err := prv.C.Exp(prv.Key, pub.X, pub.Y)
+	keyX, keyY, err := prv.sharedPoint(pub)
 	if err != nil {
 		return nil, err
 	}
-	u := big.NewInt(0).Set(ukm).Mul(ukm, prv.C.Co)
-	if u.Cmp(bigInt1) != 0 {
-		keyX, keyY, err = prv.C.Exp(u, keyX, keyY)
+	return kekFromShared(prv.C, keyX, keyY, ukm)
+}
+
+func hashKEK(key []byte, h hash.Hash) ([]byte, error) {
+	if _, err := h.Write(key); err != nil {
+		return nil, err
+	}
+	return h.Sum(key[:0]), nil
+}
+
+// Hash KEK's result.
+func (prv *PrivateKey) kekHashed(pub *PublicKey, ukm *big.Int, h hash.Hash) ([]byte, error) {
+	key, err := prv.KEK(pub, ukm)
+	if err != nil {
+		return nil, err
+	}
+	return hashKEK(key, h)
+}
+
+// Check raw UKM for VKO with h hash and convert it to the number.
+func vkoUKM(ukm []byte, h hash.Hash) (*big.Int, error) {
+	if _, ok := h.(*gost341194.Hash); ok {
+		if len(ukm) != 8 {
+			return nil, ErrInvalidUKMLength
+		}
+	} else if len(ukm) == 0 || len(ukm) > h.Size() {
+		return nil, errorf(ErrInvalidUKMLength, "gogost/gost3410: len(ukm) not in 1..%d", h.Size())
+	}
+	u := NewUKM(ukm)
+	if u.Sign() == 0 {
+		return nil, ErrZeroUKM
+	}
+	return u, nil
+}
+
+// VKO key agreement with an arbitrary hash function: KEK hashed with
+// newHash. ukm is little-endian raw UKM, as for NewUKM, and must be
+// non-zero. With GOST R 34.11-94 (RFC 4357, like KEK2001) it must be 8
+// bytes long, otherwise (RFC 7836) from 1 to the hash's size bytes.
+func (prv *PrivateKey) KEKVKO(pub *PublicKey, ukm []byte, newHash func() hash.Hash) ([]byte, error) {
+	h := newHash()
+	u, err := vkoUKM(ukm, h)
+	if err != nil {
+		return nil, err
+	}
+	return prv.kekHashed(pub, u, h)
+}
+
+// VKO key agreements with the same peer's public key and different
+// UKMs. prv*pub multiplication is made once, when session is created,
+// and each Derive makes only the UKM one. Session holds the secret
+// shared point.
+type KEKSession struct {
+	c       *Curve
+	x, y    *big.Int
+	newHash func() hash.Hash
+}
+
+// Create KEK session with the peer, checking its public key the same
+// way KEK does.
+func (prv *PrivateKey) NewKEKSession(pub *PublicKey, newHash func() hash.Hash) (*KEKSession, error) {
+	x, y, err := prv.sharedPoint(pub)
+	if err != nil {
+		return nil, err
+	}
+	return &KEKSession{c: prv.C, x: x, y: y, newHash: newHash}, nil
+}
+
+// Derive KEK with raw UKM: the same value as KEKVKO returns.
+func (s *KEKSession) Derive(ukm []byte) ([]byte, error) {
+	h := s.newHash()
+	u, err := vkoUKM(ukm, h)
+	if err != nil {
+		return nil, err
+	}
+	key, err := kekFromShared(s.c, s.x, s.y, u)
+	if err != nil {
+		return nil, err
+	}
+	return hashKEK(key, h)
+}
+
+// Derive KEKs for many UKMs, checking ctx before every derivation and
+// aborting with ctx.Err() if it is done.
+func (s *KEKSession) DeriveMany(ctx context.Context, ukms [][]byte) ([][]byte, error) {
+	keks := make([][]byte, 0, len(ukms))
+	for _, ukm := range ukms {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		kek, err := s.Derive(ukm)
 		if err != nil {
 			return nil, err
 		}
+		keks = append(keks, kek)
 	}
-	pk := PublicKey{prv.C, keyX, keyY}
-	return pk.Raw(), nil
+	return keks, nil
 }
@@ -29,13 +29,22 @@ func (prv *PrivateKey) KEK2001(pub *PublicKey, ukm *big.Int) ([]byte, error) {
 	if prv.C.PointSize() != 32 {
 		return nil, errors.New("gogost/gost3410: KEK2001 is only for 256-bit curves")
 	}
-	key, err := prv.KEK(pub, ukm)
-	if err != nil {
-		return nil, err
-	}
-	h := gost341194.New(&gost28147.SboxIdGostR341194CryptoProParamSet)
-	if _, err = h.Write(key); err != nil {
-		return nil, err
+	return prv.KEKWithParams(
+		pub, ukm,
+		&gost28147.SboxIdGostR341194CryptoProParamSet,
+	)
+}
+
+// VKO GOST R 34.10-2001 key agreement function with GOST R 34.11-94
+// hash using specified S-box instead of CryptoPro one. KEK2001 is
+// KEKWithParams with gost28147.SboxIdGostR341194CryptoProParamSet.
+func (prv *PrivateKey) KEKWithParams(
+	pub *PublicKey,
+	ukm *big.Int,
+	sbox *gost28147.Sbox,
+) ([]byte, error) {
+	if prv.C.PointSize() != 32 {
+		return nil, errors.New("gogost/gost3410: KEKWithParams is only for 256-bit curves")
 	}
-	return h.Sum(key[:0]), nil
+	return prv.kekHashed(pub, ukm, gost341194.New(sbox))
 }
@@ -21,6 +21,9 @@ import (
 	"math/big"
 	"testing"
 	"testing/quick"
+
+	"go.cypherpunks.ru/gogost/v5/gost28147"
+	"go.cypherpunks.ru/gogost/v5/gost341194"
 )
 
 func TestVKO2001(t *testing.T) {
@@ -44,6 +47,44 @@ func TestVKO2001(t *testing.T) {
 	}
 }
 
+func TestVKO2001WithParams(t *testing.T) {
+	c := CurveIdGostR34102001TestParamSet()
+	ukmRaw, _ := hex.DecodeString("5172be25f852a233")
+	ukm := NewUKM(ukmRaw)
+	prvRaw1, _ := hex.DecodeString("1df129e43dab345b68f6a852f4162dc69f36b2f84717d08755cc5c44150bf928")
+	prvRaw2, _ := hex.DecodeString("5b9356c6474f913f1e83885ea0edd5df1a43fd9d799d219093241157ac9ed473")
+	kek, _ := hex.DecodeString("ff7d181c889e11d062c0505d0d354926af33f44d606e3e6a718104b3a70ed3bb")
+	prv1, _ := NewPrivateKey(c, prvRaw1)
+	prv2, _ := NewPrivateKey(c, prvRaw2)
+	pub1, _ := prv1.PublicKey()
+	pub2, _ := prv2.PublicKey()
+	sbox := &gost28147.SboxIdGostR341194TestParamSet
+	kek1, err := prv1.KEKWithParams(pub2, ukm, sbox)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kek2, _ := prv2.KEKWithParams(pub1, ukm, sbox)
+	if bytes.Compare(kek1, kek2) != 0 {
+		t.FailNow()
+	}
+	if bytes.Compare(kek1, kek) != 0 {
+		t.FailNow()
+	}
+	raw, _ := prv1.KEK(pub2, ukm)
+	h := gost341194.New(sbox)
+	h.Write(raw)
+	if bytes.Compare(kek1, h.Sum(nil)) != 0 {
+		t.FailNow()
+	}
+	kekDefault, _ := prv1.KEKWithParams(
+		pub2, ukm, &gost28147.SboxIdGostR341194CryptoProParamSet,
+	)
+	kek2001, _ := prv1.KEK2001(pub2, ukm)
+	if bytes.Compare(kekDefault, kek2001) != 0 {
+		t.FailNow()
+	}
+}
+
 func TestVKOUKMAltering(t *testing.T) {
 	c := CurveIdtc26gost34102012256paramSetA()
 	ukm := big.NewInt(1)
@@ -67,11 +108,11 @@ func TestVKOUKMAltering(t *testing.T) {
 func TestRandomVKO2001(t *testing.T) {
 	c := CurveIdGostR34102001TestParamSet()
 	f := func(prvRaw1 [32]byte, prvRaw2 [32]byte, ukmRaw [8]byte) bool {
-		prv1, err := NewPrivateKey(c, prvRaw1[:])
+		prv1, err := NewPrivateKeyReduce(c, prvRaw1[:])
 		if err != nil {
 			return false
 		}
-		prv2, err := NewPrivateKey(c, prvRaw2[:])
+		prv2, err := NewPrivateKeyReduce(c, prvRaw2[:])
 		if err != nil {
 			return false
 		}
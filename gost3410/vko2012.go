@@ -25,27 +25,11 @@ import (
 // RFC 7836 VKO GOST R 34.10-2012 256-bit key agreement function.
 // UKM is user keying material, also called VKO-factor.
 func (prv *PrivateKey) KEK2012256(pub *PublicKey, ukm *big.Int) ([]byte, error) {
-	key, err := prv.KEK(pub, ukm)
-	if err != nil {
-		return nil, err
-	}
-	h := gost34112012256.New()
-	if _, err = h.Write(key); err != nil {
-		return nil, err
-	}
-	return h.Sum(key[:0]), nil
+	return prv.kekHashed(pub, ukm, gost34112012256.New())
 }
 
 // RFC 7836 VKO GOST R 34.10-2012 512-bit key agreement function.
 // UKM is user keying material, also called VKO-factor.
 func (prv *PrivateKey) KEK2012512(pub *PublicKey, ukm *big.Int) ([]byte, error) {
-	key, err := prv.KEK(pub, ukm)
-	if err != nil {
-		return nil, err
-	}
-	h := gost34112012512.New()
-	if _, err = h.Write(key); err != nil {
-		return nil, err
-	}
-	return h.Sum(key[:0]), nil
+	return prv.kekHashed(pub, ukm, gost34112012512.New())
 }
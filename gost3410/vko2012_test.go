@@ -17,6 +17,7 @@ package gost3410
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/hex"
 	"testing"
 	"testing/quick"
@@ -48,11 +49,11 @@ func TestVKO2012256(t *testing.T) {
 func TestRandomVKO2012256(t *testing.T) {
 	c := CurveIdtc26gost341012512paramSetA()
 	f := func(prvRaw1 [64]byte, prvRaw2 [64]byte, ukmRaw [8]byte) bool {
-		prv1, err := NewPrivateKey(c, prvRaw1[:])
+		prv1, err := NewPrivateKeyReduce(c, prvRaw1[:])
 		if err != nil {
 			return false
 		}
-		prv2, err := NewPrivateKey(c, prvRaw2[:])
+		prv2, err := NewPrivateKeyReduce(c, prvRaw2[:])
 		if err != nil {
 			return false
 		}
@@ -94,11 +95,11 @@ func TestVKO2012512(t *testing.T) {
 func TestRandomVKO2012512(t *testing.T) {
 	c := CurveIdtc26gost341012512paramSetA()
 	f := func(prvRaw1 [64]byte, prvRaw2 [64]byte, ukmRaw [8]byte) bool {
-		prv1, err := NewPrivateKey(c, prvRaw1[:])
+		prv1, err := NewPrivateKeyReduce(c, prvRaw1[:])
 		if err != nil {
 			return false
 		}
-		prv2, err := NewPrivateKey(c, prvRaw2[:])
+		prv2, err := NewPrivateKeyReduce(c, prvRaw2[:])
 		if err != nil {
 			return false
 		}
@@ -113,3 +114,77 @@ func TestRandomVKO2012512(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestRandomVKO2012256Edwards(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	f := func(prvRaw1 [32]byte, prvRaw2 [32]byte, ukmRaw [8]byte) bool {
+		prv1, err := NewPrivateKeyReduce(c, prvRaw1[:])
+		if err != nil {
+			return false
+		}
+		prv2, err := NewPrivateKeyReduce(c, prvRaw2[:])
+		if err != nil {
+			return false
+		}
+		pub1, _ := prv1.PublicKey()
+		pub2, _ := prv2.PublicKey()
+		ukm := NewUKM(ukmRaw[:])
+		kek1, _ := prv1.KEK2012256(pub2, ukm)
+		kek2, _ := prv2.KEK2012256(pub1, ukm)
+		return len(kek1) == 32 && bytes.Compare(kek1, kek2) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func benchmarkKEK(b *testing.B, c *Curve, kek func(prv *PrivateKey, pub *PublicKey, ukm []byte) error) {
+	prv1, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		b.FailNow()
+	}
+	prv2, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		b.FailNow()
+	}
+	pub2, err := prv2.PublicKey()
+	if err != nil {
+		b.FailNow()
+	}
+	ukm := make([]byte, 8)
+	rand.Read(ukm)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err = kek(prv1, pub2, ukm); err != nil {
+			b.FailNow()
+		}
+	}
+}
+
+func BenchmarkKEK2001(b *testing.B) {
+	benchmarkKEK(b, CurveIdGostR34102001CryptoProAParamSet(), func(
+		prv *PrivateKey, pub *PublicKey, ukm []byte,
+	) error {
+		_, err := prv.KEK2001(pub, NewUKM(ukm))
+		return err
+	})
+}
+
+func BenchmarkKEK2012256(b *testing.B) {
+	benchmarkKEK(b, CurveIdtc26gost341012256paramSetA(), func(
+		prv *PrivateKey, pub *PublicKey, ukm []byte,
+	) error {
+		_, err := prv.KEK2012256(pub, NewUKM(ukm))
+		return err
+	})
+}
+
+func BenchmarkKEK2012512(b *testing.B) {
+	benchmarkKEK(b, CurveIdtc26gost341012512paramSetA(), func(
+		prv *PrivateKey, pub *PublicKey, ukm []byte,
+	) error {
+		_, err := prv.KEK2012512(pub, NewUKM(ukm))
+		return err
+	})
+}
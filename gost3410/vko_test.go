@@ -0,0 +1,279 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"hash"
+	"math/big"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost28147"
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+	"go.cypherpunks.ru/gogost/v5/gost341194"
+)
+
+func TestKEKInvalidPeer(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetA()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	ukm := big.NewInt(1)
+
+	t.Run("off curve", func(t *testing.T) {
+		bad := &PublicKey{c, pub.X, big.NewInt(0).Add(pub.Y, bigInt1)}
+		if _, err := prv.KEK(bad, ukm); err == nil {
+			t.FailNow()
+		}
+		if _, err := prv.KEK2012256(bad, ukm); err == nil {
+			t.FailNow()
+		}
+	})
+
+	t.Run("infinity", func(t *testing.T) {
+		if _, err := prv.KEK(&PublicKey{c, nil, nil}, ukm); err == nil {
+			t.FailNow()
+		}
+	})
+
+	t.Run("other curve", func(t *testing.T) {
+		other := &PublicKey{CurveIdtc26gost341012256paramSetB(), pub.X, pub.Y}
+		if _, err := prv.KEK(other, ukm); err == nil {
+			t.FailNow()
+		}
+	})
+
+	t.Run("low order", func(t *testing.T) {
+		// Random curve point multiplied by the subgroup order Q belongs
+		// to the small cofactor's subgroup.
+		var x, y *big.Int
+		for x == nil {
+			xCand, err := rand.Int(rand.Reader, c.P)
+			if err != nil {
+				t.FailNow()
+			}
+			rhs := big.NewInt(0).Mul(xCand, xCand)
+			rhs.Add(rhs, c.A)
+			rhs.Mul(rhs, xCand)
+			rhs.Add(rhs, c.B)
+			rhs.Mod(rhs, c.P)
+			yCand := big.NewInt(0).ModSqrt(rhs, c.P)
+			if yCand == nil {
+				continue
+			}
+			x, y, err = c.Exp(c.Q, xCand, yCand)
+			if err != nil {
+				x = nil
+			}
+		}
+		if _, err := prv.KEK(&PublicKey{c, x, y}, ukm); err == nil {
+			t.FailNow()
+		}
+	})
+}
+
+func TestKEKVKO(t *testing.T) {
+	t.Run("2001", func(t *testing.T) {
+		c := CurveIdGostR34102001TestParamSet()
+		ukmRaw, _ := hex.DecodeString("5172be25f852a233")
+		prvRaw, _ := hex.DecodeString("1df129e43dab345b68f6a852f4162dc69f36b2f84717d08755cc5c44150bf928")
+		kek, _ := hex.DecodeString("ee4618a0dbb10cb31777b4b86a53d9e7ef6cb3e400101410f0c0f2af46c494a6")
+		prv, _ := NewPrivateKey(c, prvRaw)
+		prvRaw2, _ := hex.DecodeString("5b9356c6474f913f1e83885ea0edd5df1a43fd9d799d219093241157ac9ed473")
+		prv2, _ := NewPrivateKey(c, prvRaw2)
+		pub2, _ := prv2.PublicKey()
+		newHash := func() hash.Hash {
+			return gost341194.New(&gost28147.SboxIdGostR341194CryptoProParamSet)
+		}
+		got, err := prv.KEKVKO(pub2, ukmRaw, newHash)
+		if err != nil || bytes.Compare(got, kek) != 0 {
+			t.FailNow()
+		}
+		if _, err = prv.KEKVKO(pub2, ukmRaw[:4], newHash); err == nil {
+			t.FailNow()
+		}
+	})
+
+	c := CurveIdtc26gost341012512paramSetA()
+	ukmRaw, _ := hex.DecodeString("1d80603c8544c727")
+	prvRaw, _ := hex.DecodeString("c990ecd972fce84ec4db022778f50fcac726f46708384b8d458304962d7147f8c2db41cef22c90b102f2968404f9b9be6d47c79692d81826b32b8daca43cb667")
+	pubRaw, _ := hex.DecodeString("192fe183b9713a077253c72c8735de2ea42a3dbc66ea317838b65fa32523cd5efca974eda7c863f4954d1147f1f2b25c395fce1c129175e876d132e94ed5a65104883b414c9b592ec4dc84826f07d0b6d9006dda176ce48c391e3f97d102e03bb598bf132a228a45f7201aba08fc524a2d77e43a362ab022ad4028f75bde3b79")
+	prv, _ := NewPrivateKey(c, prvRaw)
+	pub, _ := NewPublicKey(c, pubRaw)
+
+	t.Run("2012-256", func(t *testing.T) {
+		kek, _ := hex.DecodeString("c9a9a77320e2cc559ed72dce6f47e2192ccea95fa648670582c054c0ef36c221")
+		got, err := prv.KEKVKO(pub, ukmRaw, gost34112012256.New)
+		if err != nil || bytes.Compare(got, kek) != 0 {
+			t.FailNow()
+		}
+	})
+
+	t.Run("2012-512", func(t *testing.T) {
+		kek, err := prv.KEK2012512(pub, NewUKM(ukmRaw))
+		if err != nil {
+			t.FailNow()
+		}
+		got, err := prv.KEKVKO(pub, ukmRaw, gost34112012512.New)
+		if err != nil || bytes.Compare(got, kek) != 0 {
+			t.FailNow()
+		}
+	})
+
+	t.Run("invalid ukm", func(t *testing.T) {
+		if _, err := prv.KEKVKO(pub, nil, gost34112012256.New); err == nil {
+			t.FailNow()
+		}
+		if _, err := prv.KEKVKO(pub, make([]byte, 8), gost34112012256.New); err == nil {
+			t.FailNow()
+		}
+		if _, err := prv.KEKVKO(pub, make([]byte, 33), gost34112012256.New); err == nil {
+			t.FailNow()
+		}
+	})
+}
+
+func TestKEKSession(t *testing.T) {
+	for _, tc := range []struct {
+		c       *Curve
+		newHash func() hash.Hash
+	}{
+		{CurveIdGostR34102001CryptoProXchAParamSet(), func() hash.Hash {
+			return gost341194.New(&gost28147.SboxIdGostR341194CryptoProParamSet)
+		}},
+		{CurveIdtc26gost341012256paramSetC(), gost34112012256.New},
+		{CurveIdtc26gost341012512paramSetA(), gost34112012512.New},
+	} {
+		prv, err := GenPrivateKey(tc.c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		prvPeer, err := GenPrivateKey(tc.c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pubPeer, err := prvPeer.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		session, err := prv.NewKEKSession(pubPeer, tc.newHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 4; i++ {
+			ukm := make([]byte, 8)
+			rand.Read(ukm)
+			got, err := session.Derive(ukm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ref, err := prv.KEKVKO(pubPeer, ukm, tc.newHash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Compare(got, ref) != 0 {
+				t.Fatal(tc.c.Name, ukm)
+			}
+		}
+		if _, err = session.Derive(make([]byte, 8)); err == nil {
+			t.FailNow()
+		}
+		if _, err = prv.NewKEKSession(&PublicKey{tc.c, tc.c.X, tc.c.P}, tc.newHash); err == nil {
+			t.FailNow()
+		}
+	}
+}
+
+func TestKEKCofactor(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		if c.Co.Cmp(bigInt4) != 0 {
+			t.Fatal("unexpected cofactor")
+		}
+		prv1, _ := GenPrivateKey(c, rand.Reader)
+		prv2, _ := GenPrivateKey(c, rand.Reader)
+		pub2, _ := prv2.PublicKey()
+		ukm := big.NewInt(0x1234567)
+		kek, err := prv1.KEK(pub2, ukm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Reference derivation: (m/q * ukm * prv) * pub
+		d := big.NewInt(0).Mul(c.Co, ukm)
+		d.Mul(d, prv1.Key)
+		x, y, err := c.Exp(d, pub2.X, pub2.Y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := (&PublicKey{c, x, y}).Raw()
+		if bytes.Compare(kek, expected) != 0 {
+			t.Fatal("KEK is not multiplied by the cofactor")
+		}
+		d.Mul(ukm, prv1.Key)
+		x, y, err = c.Exp(d, pub2.X, pub2.Y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Compare((&PublicKey{c, x, y}).Raw(), kek) == 0 {
+			t.Fatal("cofactor is ignored")
+		}
+	}
+}
+
+func TestKEKSessionDeriveMany(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prv, _ := GenPrivateKey(c, rand.Reader)
+	prvPeer, _ := GenPrivateKey(c, rand.Reader)
+	pubPeer, _ := prvPeer.PublicKey()
+	session, err := prv.NewKEKSession(pubPeer, gost34112012256.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ukms := make([][]byte, 4)
+	for i := range ukms {
+		ukms[i] = make([]byte, 8)
+		rand.Read(ukms[i])
+	}
+	keks, err := session.DeriveMany(context.Background(), ukms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ukm := range ukms {
+		ref, err := session.Derive(ukm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Compare(keks[i], ref) != 0 {
+			t.Fatal("different KEK")
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = session.DeriveMany(ctx, ukms); err != context.Canceled {
+		t.Fatal("cancelled context is not respected", err)
+	}
+}
@@ -0,0 +1,154 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import "encoding/binary"
+
+// Predefined curves identifiers used by the wire encoding. Identifiers
+// are part of the format, so new curves must be appended only.
+var wireCurves = []func() *Curve{
+	CurveIdtc26gost341012256paramSetA,
+	CurveIdtc26gost341012256paramSetB,
+	CurveIdtc26gost341012256paramSetC,
+	CurveIdtc26gost341012256paramSetD,
+	CurveIdtc26gost341012512paramSetTest,
+	CurveIdtc26gost341012512paramSetA,
+	CurveIdtc26gost341012512paramSetB,
+	CurveIdtc26gost341012512paramSetC,
+	CurveIdGostR34102001TestParamSet,
+	CurveIdGostR34102001CryptoProAParamSet,
+	CurveIdGostR34102001CryptoProBParamSet,
+	CurveIdGostR34102001CryptoProCParamSet,
+	CurveIdGostR34102001CryptoProXchAParamSet,
+	CurveIdGostR34102001CryptoProXchBParamSet,
+	CurveGostR34102001ParamSetcc,
+	CurveIdtc26gost34102012256paramSetA,
+	CurveIdtc26gost34102012256paramSetB,
+	CurveIdtc26gost34102012256paramSetC,
+	CurveIdtc26gost34102012256paramSetD,
+	CurveIdtc26gost34102012512paramSetTest,
+	CurveIdtc26gost34102012512paramSetA,
+	CurveIdtc26gost34102012512paramSetB,
+	CurveIdtc26gost34102012512paramSetC,
+}
+
+const wireHeaderSize = 1 + 1 + 2
+
+// Get one-byte wire identifier of the predefined curve. Curve is found
+// by its name and must be equal to the predefined one.
+func wireCurveID(c *Curve) (byte, error) {
+	for i, curve := range wireCurves {
+		if known := curve(); known.Name == c.Name && known.Equal(c) {
+			return byte(i + 1), nil
+		}
+	}
+	return 0, errorf(ErrUnknownCurve, "gogost/gost3410: curve is not predefined")
+}
+
+// Encode payload as curve identifier, digest size in bytes, big-endian
+// 16-bit payload length and the payload itself.
+func wireEncode(c *Curve, payload []byte) ([]byte, error) {
+	id, err := wireCurveID(c)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, wireHeaderSize, wireHeaderSize+len(payload))
+	data[0] = id
+	data[1] = byte(DigestSizeForCurve(c))
+	binary.BigEndian.PutUint16(data[2:], uint16(len(payload)))
+	return append(data, payload...), nil
+}
+
+// Decode data made by wireEncode, checking that the curve is known,
+// digest size corresponds to it and payload has the declared length.
+// Payload's length for the curve is checked by the caller.
+func wireDecode(data []byte) (*Curve, []byte, error) {
+	if len(data) < wireHeaderSize {
+		return nil, nil, errorf(ErrTruncatedData, "gogost/gost3410: truncated wire header")
+	}
+	id := int(data[0])
+	if id == 0 || id > len(wireCurves) {
+		return nil, nil, errorf(ErrUnknownCurve, "gogost/gost3410: unknown wire curve id %d", id)
+	}
+	c := wireCurves[id-1]()
+	if int(data[1]) != DigestSizeForCurve(c) {
+		return nil, nil, ErrDigestSizeMismatch
+	}
+	size := int(binary.BigEndian.Uint16(data[2:]))
+	payload := data[wireHeaderSize:]
+	if len(payload) < size {
+		return nil, nil, errorf(ErrTruncatedData, "gogost/gost3410: truncated wire payload")
+	}
+	if len(payload) > size {
+		return nil, nil, errorf(ErrTrailingData, "gogost/gost3410: trailing data after wire payload")
+	}
+	return c, payload, nil
+}
+
+// Encode public key to self-describing binary form: one-byte curve
+// identifier, digest size, 16-bit big-endian length and raw little-endian
+// key (as PublicKey.Raw). Curves absent in registry can not be encoded.
+func EncodeKeyWire(pub *PublicKey) ([]byte, error) {
+	return wireEncode(pub.C, pub.Raw())
+}
+
+// Decode public key made by EncodeKeyWire. Unknown curve identifiers,
+// truncated input and points not on the curve are rejected.
+func DecodeKeyWire(data []byte) (*PublicKey, error) {
+	c, raw, err := wireDecode(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewPublicKey(c, raw)
+}
+
+// Encode private key the same way EncodeKeyWire does, with raw
+// little-endian key (as PrivateKey.Raw) as the payload.
+func EncodePrivateKeyWire(prv *PrivateKey) ([]byte, error) {
+	return wireEncode(prv.C, prv.Raw())
+}
+
+// Decode private key made by EncodePrivateKeyWire. Key must be in the
+// range NewPrivateKey accepts.
+func DecodePrivateKeyWire(data []byte) (*PrivateKey, error) {
+	c, raw, err := wireDecode(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(c, raw)
+}
+
+// Encode signature made with curve's key the same way EncodeKeyWire
+// does, with the signature (as SignDigest returns it) as the payload.
+func EncodeSignatureWire(c *Curve, sig []byte) ([]byte, error) {
+	if len(sig) != 2*c.PointSize() {
+		return nil, errorf(ErrInvalidSignatureLength, "gogost/gost3410: len(signature) != %d", 2*c.PointSize())
+	}
+	return wireEncode(c, sig)
+}
+
+// Decode signature made by EncodeSignatureWire, returning the curve it
+// was made on. Signature is not verified.
+func DecodeSignatureWire(data []byte) (*Curve, []byte, error) {
+	c, sig, err := wireDecode(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sig) != 2*c.PointSize() {
+		return nil, nil, errorf(ErrInvalidSignatureLength, "gogost/gost3410: len(signature) != %d", 2*c.PointSize())
+	}
+	return c, append([]byte(nil), sig...), nil
+}
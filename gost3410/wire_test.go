@@ -0,0 +1,156 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestWireCurves(t *testing.T) {
+	if len(wireCurves) != len(curves) {
+		t.Fatal("not all curves have wire identifiers")
+	}
+	for _, curve := range curves {
+		c := curve()
+		id, err := wireCurveID(c)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		if wireCurves[id-1]().Name != c.Name {
+			t.Fatal(c.Name, "wrong wire identifier")
+		}
+	}
+}
+
+func TestWireRoundTrip(t *testing.T) {
+	for _, curve := range curves {
+		c := curve()
+		prv, err := GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		digest := make([]byte, c.PointSize())
+		rand.Read(digest)
+		sig, err := prv.SignDigest(digest, rand.Reader)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+
+		data, err := EncodeKeyWire(pub)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		if len(data) != 4+2*c.PointSize() || int(data[1]) != DigestSizeForCurve(c) {
+			t.Fatal(c.Name, "unexpected public key encoding")
+		}
+		pubGot, err := DecodeKeyWire(data)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		if pubGot.C.Name != c.Name || !pubGot.Equal(pub) {
+			t.Fatal(c.Name, "public key differs")
+		}
+
+		data, err = EncodePrivateKeyWire(prv)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		prvGot, err := DecodePrivateKeyWire(data)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		if prvGot.C.Name != c.Name || !prvGot.Equal(prv) {
+			t.Fatal(c.Name, "private key differs")
+		}
+
+		data, err = EncodeSignatureWire(c, sig)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		cGot, sigGot, err := DecodeSignatureWire(data)
+		if err != nil {
+			t.Fatal(c.Name, err)
+		}
+		if cGot.Name != c.Name || bytes.Compare(sigGot, sig) != 0 {
+			t.Fatal(c.Name, "signature differs")
+		}
+		valid, err := pubGot.VerifyDigest(digest, sigGot)
+		if err != nil || !valid {
+			t.Fatal(c.Name, "decoded signature is not verified")
+		}
+	}
+}
+
+func TestWireMalformed(t *testing.T) {
+	c := CurveIdtc26gost341012256paramSetB()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := EncodeKeyWire(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(data); i++ {
+		if _, err = DecodeKeyWire(data[:i]); !errors.Is(err, ErrTruncatedData) {
+			t.Fatal(i, err)
+		}
+	}
+	if _, err = DecodeKeyWire(append(data, 0)); !errors.Is(err, ErrTrailingData) {
+		t.Fatal(err)
+	}
+	for _, id := range []byte{0, byte(len(wireCurves) + 1), 0xFF} {
+		unknown := append([]byte{id}, data[1:]...)
+		if _, err = DecodeKeyWire(unknown); !errors.Is(err, ErrUnknownCurve) {
+			t.Fatal(id, err)
+		}
+	}
+	mismatch := append([]byte(nil), data...)
+	mismatch[1] = 64
+	if _, err = DecodeKeyWire(mismatch); !errors.Is(err, ErrDigestSizeMismatch) {
+		t.Fatal(err)
+	}
+	short := append([]byte(nil), data[:len(data)-1]...)
+	short[3]--
+	if _, err = DecodeKeyWire(short); !errors.Is(err, ErrInvalidKeyLength) {
+		t.Fatal(err)
+	}
+	if _, _, err = DecodeSignatureWire(short); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Fatal(err)
+	}
+	if _, err = DecodePrivateKeyWire(data); !errors.Is(err, ErrInvalidKeyLength) {
+		t.Fatal(err)
+	}
+	if _, err = EncodeSignatureWire(c, make([]byte, 63)); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Fatal(err)
+	}
+	custom := *c
+	custom.Name = "custom"
+	if _, err = EncodeKeyWire(&PublicKey{&custom, pub.X, pub.Y}); !errors.Is(err, ErrUnknownCurve) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,227 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3410
+
+import (
+	"crypto/rand"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// Wycheproof-like test case: each of them is either valid, or must be
+// rejected without panic.
+type wycheproofVector struct {
+	tcID    int
+	comment string
+	pub     *PublicKey
+	digest  []byte
+	sig     []byte
+	der     bool
+	valid   bool
+}
+
+type wycheproofGroup struct {
+	curve *Curve
+	tests []wycheproofVector
+}
+
+func (g *wycheproofGroup) add(comment string, pub *PublicKey, digest, sig []byte, der, valid bool) {
+	g.tests = append(g.tests, wycheproofVector{
+		tcID:    len(g.tests) + 1,
+		comment: comment,
+		pub:     pub,
+		digest:  digest,
+		sig:     sig,
+		der:     der,
+		valid:   valid,
+	})
+}
+
+func wycheproofDER(r, s *big.Int) []byte {
+	der, err := asn1.Marshal(signatureDER{R: r, S: s})
+	if err != nil {
+		panic(err)
+	}
+	return der
+}
+
+func newWycheproofGroup(t *testing.T, c *Curve) *wycheproofGroup {
+	pointSize := c.PointSize()
+	prv, err := GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := make([]byte, pointSize)
+	rand.Read(digest)
+	sig, err := prv.SignDigest(digest, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := bytes2big(sig[:pointSize])
+	r := bytes2big(sig[pointSize:])
+	native := func(r, s *big.Int) []byte {
+		return append(pad(s.Bytes(), pointSize), pad(r.Bytes(), pointSize)...)
+	}
+	der := wycheproofDER(r, s)
+	g := wycheproofGroup{curve: c}
+
+	g.add("valid signature", pub, digest, sig, false, true)
+	g.add("valid DER signature", pub, digest, der, true, true)
+
+	// Signature values range
+	zero := big.NewInt(0)
+	rPlusQ := big.NewInt(0).Add(r, c.Q)
+	sPlusQ := big.NewInt(0).Add(s, c.Q)
+	for _, v := range []struct {
+		comment string
+		r, s    *big.Int
+	}{
+		{"r = 0", zero, s},
+		{"s = 0", r, zero},
+		{"r = s = 0", zero, zero},
+		{"r = Q", c.Q, s},
+		{"s = Q", r, c.Q},
+		{"r = Q - 1", big.NewInt(0).Sub(c.Q, bigInt1), s},
+		{"s = 1", r, bigInt1},
+		{"r = s = 1", bigInt1, bigInt1},
+		{"r + Q", rPlusQ, s},
+		{"s + Q", r, sPlusQ},
+		{"swapped r and s", s, r},
+		{"r = P", c.P, s},
+	} {
+		if len(v.r.Bytes()) <= pointSize && len(v.s.Bytes()) <= pointSize {
+			g.add(v.comment, pub, digest, native(v.r, v.s), false, false)
+		}
+		if v.r.Sign() > 0 && v.s.Sign() > 0 {
+			g.add(v.comment+" (DER)", pub, digest, wycheproofDER(v.r, v.s), true, false)
+		}
+	}
+	g.add("negative r (DER)", pub, digest, wycheproofDER(big.NewInt(0).Neg(r), s), true, false)
+	g.add("negative s (DER)", pub, digest, wycheproofDER(r, big.NewInt(0).Neg(s)), true, false)
+	g.add("zero r (DER)", pub, digest, wycheproofDER(zero, s), true, false)
+
+	// Native signature length
+	g.add("empty signature", pub, digest, nil, false, false)
+	g.add("truncated signature", pub, digest, sig[:len(sig)-1], false, false)
+	g.add("signature with appended zero", pub, digest, append(append([]byte{}, sig...), 0), false, false)
+	g.add("signature with prepended zero", pub, digest, append([]byte{0}, sig...), false, false)
+	g.add("half of signature", pub, digest, sig[:pointSize], false, false)
+	g.add("doubled signature", pub, digest, append(append([]byte{}, sig...), sig...), false, false)
+	flipped := append([]byte{}, sig...)
+	flipped[len(flipped)-1] ^= 1
+	g.add("flipped bit in r", pub, digest, flipped, false, false)
+	flipped = append([]byte{}, sig...)
+	flipped[0] ^= 0x80
+	g.add("flipped bit in s", pub, digest, flipped, false, false)
+
+	// DER encoding
+	rB := r.Bytes()
+	sB := s.Bytes()
+	integer := func(b []byte) []byte {
+		return append([]byte{0x02, byte(len(b))}, b...)
+	}
+	seq := func(tag byte, items ...[]byte) []byte {
+		var body []byte
+		for _, item := range items {
+			body = append(body, item...)
+		}
+		return append([]byte{tag, byte(len(body))}, body...)
+	}
+	if len(rB) < 127-3 {
+		g.add("extra leading zero in r", pub, digest,
+			seq(0x30, integer(append([]byte{0x00, 0x00}, rB...)), integer(sB)), true, false)
+		g.add("extra leading zero in s", pub, digest,
+			seq(0x30, integer(rB), integer(append([]byte{0x00, 0x00}, sB...))), true, false)
+		g.add("leading 0xFF in r", pub, digest,
+			seq(0x30, integer(append([]byte{0xFF}, rB...)), integer(sB)), true, false)
+	}
+	g.add("trailing data after DER", pub, digest, append(append([]byte{}, der...), 0), true, false)
+	g.add("truncated DER", pub, digest, der[:len(der)-1], true, false)
+	g.add("empty DER", pub, digest, nil, true, false)
+	g.add("empty SEQUENCE", pub, digest, []byte{0x30, 0x00}, true, false)
+	g.add("SET instead of SEQUENCE", pub, digest,
+		append([]byte{0x31}, der[1:]...), true, false)
+	g.add("indefinite length", pub, digest,
+		append(append([]byte{0x30, 0x80}, der[2:]...), 0, 0), true, false)
+	g.add("single INTEGER", pub, digest, integer(rB), true, false)
+	g.add("OCTET STRING instead of INTEGER", pub, digest,
+		seq(0x30, append([]byte{0x04, byte(len(rB))}, rB...), integer(sB)), true, false)
+	g.add("three INTEGERs", pub, digest,
+		seq(0x30, integer(rB), integer(sB), integer([]byte{1})), true, false)
+	g.add("r and s swapped in DER", pub, digest, wycheproofDER(s, r), true, false)
+	g.add("native signature as DER", pub, digest, sig, true, false)
+
+	// Digests
+	other := append([]byte{}, digest...)
+	other[0] ^= 1
+	g.add("other digest", pub, other, sig, false, false)
+	g.add("empty digest", pub, nil, sig, false, false)
+	g.add("longer digest", pub, append(append([]byte{}, digest...), 0), sig, false, false)
+	g.add("shorter digest", pub, digest[:pointSize-1], sig, false, false)
+	// Digest is reduced modulo Q, as SignDigest does
+	g.add("digest plus Q", pub, pad(big.NewInt(0).Add(bytes2big(digest), c.Q).Bytes(), 2*pointSize), sig, false, true)
+
+	// Public keys
+	pubOther, _ := prv.PublicKey()
+	pubOther.Y = big.NewInt(0).Sub(c.P, pubOther.Y)
+	g.add("negated public key", pubOther, digest, sig, false, false)
+	g.add("public key is point at infinity", &PublicKey{c, nil, nil}, digest, sig, false, false)
+	g.add("public key is (0, 0)", &PublicKey{c, big.NewInt(0), big.NewInt(0)}, digest, sig, false, false)
+	g.add("public key is off the curve", &PublicKey{c, pub.X, big.NewInt(0).Add(pub.Y, bigInt1)}, digest, sig, false, false)
+	g.add("public key is base point", &PublicKey{c, c.X, c.Y}, digest, sig, false, false)
+	g.add("public key X is not reduced", &PublicKey{c, big.NewInt(0).Add(pub.X, c.P), pub.Y}, digest, sig, false, false)
+	return &g
+}
+
+func (v *wycheproofVector) verify() (valid bool, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	if v.der {
+		return VerifyASN1(v.pub, v.digest, v.sig), nil
+	}
+	valid, _ = v.pub.VerifyDigest(v.digest, v.sig)
+	return valid, nil
+}
+
+func TestWycheproofVectors(t *testing.T) {
+	for _, c := range []*Curve{
+		CurveIdGostR34102001CryptoProAParamSet(),
+		CurveIdtc26gost341012256paramSetA(),
+		CurveIdtc26gost341012512paramSetA(),
+		CurveIdtc26gost341012512paramSetC(),
+	} {
+		g := newWycheproofGroup(t, c)
+		for _, v := range g.tests {
+			valid, err := v.verify()
+			if err != nil {
+				t.Errorf("%s tcId %d (%s): %v", c.Name, v.tcID, v.comment, err)
+				continue
+			}
+			if valid != v.valid {
+				t.Errorf("%s tcId %d (%s): valid=%v", c.Name, v.tcID, v.comment, valid)
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// HMAC (RFC 2104) and PBKDF2 (RFC 8018) instantiated with GOST R 34.11
+// hash functions: HMAC_GOSTR3411_2012_{256,512} from RFC 7836 and
+// PBKDF2 from Р 50.1.111-2016.
+package gost3411
+
+import (
+	"crypto/hmac"
+	"hash"
+
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+)
+
+// HMAC_GOSTR3411_2012_256: HMAC with 256-bit Streebog.
+func HMAC256(key []byte) hash.Hash {
+	return hmac.New(gost34112012256.New, key)
+}
+
+// HMAC_GOSTR3411_2012_512: HMAC with 512-bit Streebog.
+func HMAC512(key []byte) hash.Hash {
+	return hmac.New(gost34112012512.New, key)
+}
@@ -0,0 +1,27 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3411
+
+import (
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// PBKDF2 (RFC 8018) with HMAC_GOSTR3411_2012_512 PRF, as
+// Р 50.1.111-2016 defines.
+func PBKDF2(password, salt []byte, iter, keyLen int) []byte {
+	return pbkdf2.Key(password, salt, iter, keyLen, gost34112012512.New)
+}
@@ -0,0 +1,57 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost34112012256
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHashM1(t *testing.T) {
+	h := New()
+	if h.Size() != Size || h.BlockSize() != BlockSize {
+		t.FailNow()
+	}
+	h.Write([]byte("012345678901234567890123456789012345678901234567890123456789012"))
+	if bytes.Compare(h.Sum(nil), []byte{
+		0x9d, 0x15, 0x1e, 0xef, 0xd8, 0x59, 0x0b, 0x89,
+		0xda, 0xa6, 0xba, 0x6c, 0xb7, 0x4a, 0xf9, 0x27,
+		0x5d, 0xd0, 0x51, 0x02, 0x6b, 0xb1, 0x49, 0xa4,
+		0x52, 0xfd, 0x84, 0xe5, 0xe5, 0x7b, 0x55, 0x00,
+	}) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestHashCheckpoint(t *testing.T) {
+	h := New()
+	h.Write([]byte("0123"))
+	c, ok := h.(interface{ Checkpoint() []byte })
+	if !ok {
+		t.FailNow()
+	}
+	prefix := c.Checkpoint()
+	h.Write([]byte("4567"))
+	ref := New()
+	ref.Write([]byte("0123"))
+	if bytes.Compare(prefix, ref.Sum(nil)) != 0 {
+		t.FailNow()
+	}
+	ref.Write([]byte("4567"))
+	if bytes.Compare(c.Checkpoint(), ref.Sum(nil)) != 0 {
+		t.FailNow()
+	}
+}
@@ -17,9 +17,11 @@ package gost34112012256
 
 import (
 	"crypto/hmac"
+	"encoding/binary"
 	"hash"
 )
 
+// KDF_GOSTR3411_2012_256 (RFC 7836 4.5) keyed with the given key.
 type KDF struct {
 	h hash.Hash
 }
@@ -51,3 +53,32 @@ func (kdf *KDF) Derive(dst, label, seed []byte) (r []byte) {
 	kdf.h.Reset()
 	return r
 }
+
+// KDF_TREE_GOSTR3411_2012_256 (RFC 7836 4.5): derive keys*Size bytes of
+// keying material, appending them to dst. r is the counter's length in
+// bytes, from 1 to 4.
+func (kdf *KDF) DeriveTree(dst, label, seed []byte, keys, r int) []byte {
+	if r < 1 || r > 4 {
+		panic("invalid counter length")
+	}
+	if keys < 1 || uint64(keys) >= uint64(1)<<uint(8*r) {
+		panic("invalid keys number")
+	}
+	l := make([]byte, 8)
+	binary.BigEndian.PutUint64(l, uint64(keys)*Size*8)
+	for len(l) > 1 && l[0] == 0 {
+		l = l[1:]
+	}
+	ctr := make([]byte, 4)
+	for i := 1; i <= keys; i++ {
+		binary.BigEndian.PutUint32(ctr, uint32(i))
+		for _, data := range [][]byte{ctr[4-r:], label, {0x00}, seed, l} {
+			if _, err := kdf.h.Write(data); err != nil {
+				panic(err)
+			}
+		}
+		dst = kdf.h.Sum(dst)
+		kdf.h.Reset()
+	}
+	return dst
+}
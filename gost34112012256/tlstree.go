@@ -60,6 +60,7 @@ type TLSTree struct {
 	seqNumPrev uint64
 	seq        []byte
 	key        []byte
+	derived    bool
 }
 
 func NewTLSTree(params TLSTreeParams, keyRoot []byte) *TLSTree {
@@ -74,7 +75,7 @@ func NewTLSTree(params TLSTreeParams, keyRoot []byte) *TLSTree {
 }
 
 func (t *TLSTree) DeriveCached(seqNum uint64) ([]byte, bool) {
-	if seqNum > 0 &&
+	if t.derived &&
 		(seqNum&t.params[0]) == ((t.seqNumPrev)&t.params[0]) &&
 		(seqNum&t.params[1]) == ((t.seqNumPrev)&t.params[1]) &&
 		(seqNum&t.params[2]) == ((t.seqNumPrev)&t.params[2]) {
@@ -88,6 +89,7 @@ func (t *TLSTree) DeriveCached(seqNum uint64) ([]byte, bool) {
 	binary.BigEndian.PutUint64(t.seq, seqNum&t.params[2])
 	kdf3.Derive(t.key[:0], []byte("level3"), t.seq)
 	t.seqNumPrev = seqNum
+	t.derived = true
 	return t.key, false
 }
 
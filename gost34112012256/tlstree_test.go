@@ -185,3 +185,28 @@ func TestTLSTreeKuznyechikCTROMAC(t *testing.T) {
 		}
 	})
 }
+
+func TestTLSTreeFirstDeriveNonZero(t *testing.T) {
+	keyRoot := []byte{
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+	}
+	tt := NewTLSTree(TLSGOSTR341112256WithKuznyechikCTROMAC, keyRoot)
+	got, cached := tt.DeriveCached(63)
+	if cached {
+		t.FailNow()
+	}
+	if bytes.Compare(got, []byte{
+		0x50, 0x76, 0x42, 0xD9, 0x58, 0xC5, 0x20, 0xC6,
+		0xD7, 0xEE, 0xF5, 0xCA, 0x8A, 0x53, 0x16, 0xD4,
+		0xF3, 0x4B, 0x85, 0x5D, 0x2D, 0xD4, 0xBC, 0xBF,
+		0x4E, 0x5B, 0xF0, 0xFF, 0x64, 0x1A, 0x19, 0xFF,
+	}) != 0 {
+		t.FailNow()
+	}
+	if _, cached = tt.DeriveCached(0); !cached {
+		t.FailNow()
+	}
+}
@@ -34,6 +34,8 @@ func init() {
 }
 */
 
+// Hash also has Checkpoint() []byte method, returning the digest of
+// the data written so far without changing the state.
 func New() hash.Hash {
 	return gost34112012.New(64)
 }
@@ -0,0 +1,41 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost34112012512
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHashM1(t *testing.T) {
+	h := New()
+	if h.Size() != Size || h.BlockSize() != BlockSize {
+		t.FailNow()
+	}
+	h.Write([]byte("012345678901234567890123456789012345678901234567890123456789012"))
+	if bytes.Compare(h.Sum(nil), []byte{
+		0x1b, 0x54, 0xd0, 0x1a, 0x4a, 0xf5, 0xb9, 0xd5,
+		0xcc, 0x3d, 0x86, 0xd6, 0x8d, 0x28, 0x54, 0x62,
+		0xb1, 0x9a, 0xbc, 0x24, 0x75, 0x22, 0x2f, 0x35,
+		0xc0, 0x85, 0x12, 0x2b, 0xe4, 0xba, 0x1f, 0xfa,
+		0x00, 0xad, 0x30, 0xf8, 0x76, 0x7b, 0x3a, 0x82,
+		0x38, 0x4c, 0x65, 0x74, 0xf0, 0x24, 0xc3, 0x11,
+		0xe2, 0xa4, 0x81, 0x33, 0x2b, 0x08, 0xef, 0x7f,
+		0x41, 0x79, 0x78, 0x91, 0xc1, 0x64, 0x6f, 0x48,
+	}) != 0 {
+		t.FailNow()
+	}
+}
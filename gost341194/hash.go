@@ -18,7 +18,10 @@
 package gost341194
 
 import (
+	"bytes"
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"go.cypherpunks.ru/gogost/v5/gost28147"
@@ -27,6 +30,8 @@ import (
 const (
 	BlockSize = 32
 	Size      = 32
+
+	MarshaledName = "GOST341194"
 )
 
 var (
@@ -69,6 +74,15 @@ func New(sbox *gost28147.Sbox) *Hash {
 	return &h
 }
 
+// Compute GOST R 34.11-94 digest of data with sbox, like sha256.Sum256
+// does.
+func Sum(data []byte, sbox *gost28147.Sbox) (digest [Size]byte) {
+	h := New(sbox)
+	h.Write(data)
+	copy(digest[:], h.Sum(nil))
+	return
+}
+
 func (h *Hash) Reset() {
 	h.size = 0
 	h.hsh = [BlockSize]byte{
@@ -267,3 +281,47 @@ func (h *Hash) Sum(in []byte) []byte {
 	blockReverse(hsh[:], hsh[:])
 	return append(in, hsh[:]...)
 }
+
+// Serialize hash state: processed length, chaining value, checksum and
+// buffered incomplete block. S-box is not included: unmarshal into hash
+// created with the same one.
+func (h *Hash) MarshalBinary() (data []byte, err error) {
+	data = make([]byte, len(MarshaledName)+8+2*BlockSize+len(h.buf))
+	copy(data, []byte(MarshaledName))
+	idx := len(MarshaledName)
+	binary.BigEndian.PutUint64(data[idx:idx+8], h.size)
+	idx += 8
+	copy(data[idx:], h.hsh[:])
+	idx += BlockSize
+	chkBytes := h.chk.Bytes()
+	copy(data[idx+BlockSize-len(chkBytes):], chkBytes)
+	idx += BlockSize
+	copy(data[idx:], h.buf)
+	return
+}
+
+func (h *Hash) UnmarshalBinary(data []byte) error {
+	expectedLen := len(MarshaledName) + 8 + 2*BlockSize
+	if len(data) < expectedLen {
+		return fmt.Errorf("gogost/gost341194: len(data) < %d", expectedLen)
+	}
+	if len(data) >= expectedLen+BlockSize {
+		return errors.New("gogost/gost341194: too long buffered data")
+	}
+	if !bytes.HasPrefix(data, []byte(MarshaledName)) {
+		return errors.New("gogost/gost341194: no hash name prefix")
+	}
+	idx := len(MarshaledName)
+	size := binary.BigEndian.Uint64(data[idx : idx+8])
+	if size%(BlockSize*8) != 0 {
+		return errors.New("gogost/gost341194: invalid processed length")
+	}
+	h.size = size
+	idx += 8
+	copy(h.hsh[:], data[idx:])
+	idx += BlockSize
+	h.chk = big.NewInt(0).SetBytes(data[idx : idx+BlockSize])
+	idx += BlockSize
+	h.buf = append(h.buf[:0], data[idx:]...)
+	return nil
+}
@@ -18,6 +18,7 @@ package gost341194
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding"
 	"hash"
 	"testing"
 	"testing/quick"
@@ -28,6 +29,8 @@ import (
 func TestHashInterface(t *testing.T) {
 	h := New(SboxDefault)
 	var _ hash.Hash = h
+	var _ encoding.BinaryMarshaler = h
+	var _ encoding.BinaryUnmarshaler = h
 }
 
 func TestVectors(t *testing.T) {
@@ -277,6 +280,51 @@ func TestRandom(t *testing.T) {
 	}
 }
 
+func TestMarshalResume(t *testing.T) {
+	data := make([]byte, 1<<20+13)
+	rand.Read(data)
+	h := New(SboxDefault)
+	h.Write(data)
+	hsh := h.Sum(nil)
+	f := func(split uint32) bool {
+		n := int(split) % len(data)
+		h := New(SboxDefault)
+		h.Write(data[:n])
+		raw, err := h.MarshalBinary()
+		if err != nil {
+			return false
+		}
+		hNew := New(SboxDefault)
+		if err = hNew.UnmarshalBinary(raw); err != nil {
+			return false
+		}
+		hNew.Write(data[n:])
+		return bytes.Compare(hNew.Sum(nil), hsh) == 0
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 8}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	h := New(SboxDefault)
+	h.Write([]byte("abc"))
+	raw, err := h.MarshalBinary()
+	if err != nil {
+		t.FailNow()
+	}
+	if h.UnmarshalBinary(raw[:len(raw)-4]) == nil {
+		t.FailNow()
+	}
+	if h.UnmarshalBinary(append(raw, make([]byte, BlockSize)...)) == nil {
+		t.FailNow()
+	}
+	raw[0] ^= 1
+	if h.UnmarshalBinary(raw) == nil {
+		t.FailNow()
+	}
+}
+
 func BenchmarkHash(b *testing.B) {
 	h := New(SboxDefault)
 	src := make([]byte, BlockSize+1)
@@ -287,3 +335,35 @@ func BenchmarkHash(b *testing.B) {
 		h.Sum(nil)
 	}
 }
+
+func TestSum(t *testing.T) {
+	sbox := &gost28147.SboxIdGostR341194CryptoProParamSet
+	h := New(sbox)
+	if h.Size() != 32 || h.BlockSize() != 32 {
+		t.FailNow()
+	}
+	if digest := Sum(nil, sbox); bytes.Compare(digest[:], []byte{
+		0x98, 0x1e, 0x5f, 0x3c, 0xa3, 0x0c, 0x84, 0x14,
+		0x87, 0x83, 0x0f, 0x84, 0xfb, 0x43, 0x3e, 0x13,
+		0xac, 0x11, 0x01, 0x56, 0x9b, 0x9c, 0x13, 0x58,
+		0x4a, 0xc4, 0x83, 0x23, 0x4c, 0xd6, 0x56, 0xc0,
+	}) != 0 {
+		t.FailNow()
+	}
+	data := bytes.Repeat([]byte{'U'}, 128)
+	digest := Sum(data, sbox)
+	if bytes.Compare(digest[:], []byte{
+		0x1c, 0x4a, 0xc7, 0x61, 0x46, 0x91, 0xbb, 0xf4,
+		0x27, 0xfa, 0x23, 0x16, 0x21, 0x6b, 0xe8, 0xf1,
+		0x0d, 0x92, 0xed, 0xfd, 0x37, 0xcd, 0x10, 0x27,
+		0x51, 0x4c, 0x10, 0x08, 0xf6, 0x49, 0xc4, 0xe8,
+	}) != 0 {
+		t.FailNow()
+	}
+	h.Write([]byte("garbage"))
+	h.Reset()
+	h.Write(data)
+	if bytes.Compare(h.Sum(nil), digest[:]) != 0 {
+		t.FailNow()
+	}
+}
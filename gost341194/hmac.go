@@ -0,0 +1,30 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost341194
+
+import (
+	"crypto/hmac"
+	"hash"
+
+	"go.cypherpunks.ru/gogost/v5/gost28147"
+)
+
+// HMAC (RFC 2104) with GOST R 34.11-94 hash under sbox, as used by
+// legacy CryptoPro systems (HMAC_GOSTR3411, RFC 4357 3.1). Hash's
+// 32-byte block size is used for key padding.
+func NewHMAC(key []byte, sbox *gost28147.Sbox) hash.Hash {
+	return hmac.New(func() hash.Hash { return New(sbox) }, key)
+}
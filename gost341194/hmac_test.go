@@ -0,0 +1,55 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost341194
+
+import (
+	"bytes"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost28147"
+)
+
+// Single PBKDF2 iteration from TestPBKDF2Vectors is HMAC of salt with
+// big-endian block number 1 appended.
+func TestHMACVector(t *testing.T) {
+	m := NewHMAC([]byte("password"), &gost28147.SboxIdGostR341194CryptoProParamSet)
+	if m.BlockSize() != BlockSize || m.Size() != Size {
+		t.FailNow()
+	}
+	m.Write([]byte("salt"))
+	m.Write([]byte{0, 0, 0, 1})
+	if bytes.Compare(m.Sum(nil), []byte{
+		0x73, 0x14, 0xe7, 0xc0, 0x4f, 0xb2, 0xe6, 0x62,
+		0xc5, 0x43, 0x67, 0x42, 0x53, 0xf6, 0x8b, 0xd0,
+		0xb7, 0x34, 0x45, 0xd0, 0x7f, 0x24, 0x1b, 0xed,
+		0x87, 0x28, 0x82, 0xda, 0x21, 0x66, 0x2d, 0x58,
+	}) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestHMACLongKey(t *testing.T) {
+	sbox := &gost28147.SboxIdGostR341194CryptoProParamSet
+	key := bytes.Repeat([]byte{0x01}, BlockSize+1)
+	keyHashed := Sum(key, sbox)
+	m1 := NewHMAC(key, sbox)
+	m2 := NewHMAC(keyHashed[:], sbox)
+	m1.Write([]byte("data"))
+	m2.Write([]byte("data"))
+	if bytes.Compare(m1.Sum(nil), m2.Sum(nil)) != 0 {
+		t.FailNow()
+	}
+}
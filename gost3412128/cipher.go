@@ -195,6 +195,8 @@ func (c *Cipher) BlockSize() int {
 	return BlockSize
 }
 
+// Create Kuznechik cipher instance with precomputed round keys.
+// Panics if key is not KeySize long.
 func NewCipher(key []byte) *Cipher {
 	if len(key) != KeySize {
 		panic("invalid key size")
@@ -14,6 +14,13 @@
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 // GOST 34.12-2015 64-bit (Магма (Magma)) block cipher.
+//
+// Magma is GOST 28147-89 with the fixed id-tc26-gost-28147-param-Z
+// S-box, but with different byte order: 28147-89 treats the key as
+// little-endian 32-bit words and the block as two little-endian halves,
+// while 34.12-2015 uses big-endian representation of both. So the same
+// key and plaintext bytes give different results with gost28147 and
+// this package. This package converts and delegates to gost28147.
 package gost341264
 
 import (
@@ -30,6 +37,8 @@ type Cipher struct {
 	blk *[BlockSize]byte
 }
 
+// Create Magma cipher instance. Key is in 34.12-2015 (big-endian) byte
+// order. Panics if key is not KeySize long.
 func NewCipher(key []byte) *Cipher {
 	if len(key) != KeySize {
 		panic("invalid key size")
@@ -0,0 +1,128 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"crypto/cipher"
+)
+
+// ACPKM key size: both Kuznechik and Magma have 256-bit keys.
+const acpkmKeySize = 32
+
+// ACPKM re-keying transformation (RFC 8645 section 6.2.1): new key
+// is the encryption of D = 0x80||0x81||...||0x9F constant under the
+// current key.
+func ACPKM(b cipher.Block) []byte {
+	key := make([]byte, acpkmKeySize)
+	for i := 0; i < acpkmKeySize; i++ {
+		key[i] = byte(0x80 + i)
+	}
+	blockSize := b.BlockSize()
+	for i := 0; i < acpkmKeySize; i += blockSize {
+		b.Encrypt(key[i:i+blockSize], key[i:i+blockSize])
+	}
+	return key
+}
+
+type ctrACPKM struct {
+	newCipher func(key []byte) cipher.Block
+	b         cipher.Block
+	blockSize int
+	section   int
+	processed int
+	ctr       []byte
+	gamma     []byte
+	used      int
+}
+
+// CTR-ACPKM mode (RFC 8645 section 6.2.2): CTR, where the key is
+// changed with ACPKM transformation after each section bytes of
+// keystream. iv is half of the block size long, like in NewCTR.
+// Section size must be a positive multiple of the block size.
+// newCipher is used to create ciphers with the initial and derived
+// keys, so a ready cipher.Block is not enough.
+func NewCTRACPKM(
+	newCipher func(key []byte) cipher.Block,
+	key, iv []byte,
+	section int,
+) cipher.Stream {
+	b := newCipher(key)
+	blockSize := b.BlockSize()
+	if len(iv) != blockSize/2 {
+		panic("iv length is not equal to half of blocksize")
+	}
+	if section <= 0 || section%blockSize != 0 {
+		panic("section size is not multiple of blocksize")
+	}
+	c := ctrACPKM{
+		newCipher: newCipher,
+		b:         b,
+		blockSize: blockSize,
+		section:   section,
+		ctr:       make([]byte, blockSize),
+		gamma:     make([]byte, blockSize),
+		used:      blockSize,
+	}
+	copy(c.ctr, iv)
+	return &c
+}
+
+func (c *ctrACPKM) next() {
+	if c.processed == c.section {
+		c.b = c.newCipher(ACPKM(c.b))
+		c.processed = 0
+	}
+	c.b.Encrypt(c.gamma, c.ctr)
+	for i := c.blockSize - 1; i >= 0; i-- {
+		c.ctr[i]++
+		if c.ctr[i] != 0 {
+			break
+		}
+	}
+	c.processed += c.blockSize
+	c.used = 0
+}
+
+func (c *ctrACPKM) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("dst is too short")
+	}
+	for i := 0; i < len(src); i++ {
+		if c.used == c.blockSize {
+			c.next()
+		}
+		dst[i] = src[i] ^ c.gamma[c.used]
+		c.used++
+	}
+}
+
+// ACPKM-Master key derivation (RFC 8645 section 6.3.1): size bytes of
+// CTR-ACPKM keystream with section size and all-ones iv. It is used to
+// derive keys for OMAC-ACPKM.
+func ACPKMMaster(
+	newCipher func(key []byte) cipher.Block,
+	key []byte,
+	section, size int,
+) []byte {
+	b := newCipher(key)
+	iv := make([]byte, b.BlockSize()/2)
+	for i := 0; i < len(iv); i++ {
+		iv[i] = 0xFF
+	}
+	out := make([]byte, size)
+	NewCTRACPKM(newCipher, key, iv, section).XORKeyStream(out, out)
+	return out
+}
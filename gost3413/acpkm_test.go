@@ -0,0 +1,171 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost3412128"
+	"go.cypherpunks.ru/gogost/v5/gost341264"
+)
+
+func newKuznechik(key []byte) cipher.Block {
+	return gost3412128.NewCipher(key)
+}
+
+func newMagma(key []byte) cipher.Block {
+	return gost341264.NewCipher(key)
+}
+
+// RFC 8645 Appendix A.1, first four blocks: the first section equals
+// ordinary CTR, following ones are produced under ACPKM-derived key.
+func TestCTRACPKMVector(t *testing.T) {
+	iv, _ := hex.DecodeString("1234567890abcef0")
+	ct, _ := hex.DecodeString(
+		"f195d8bec10ed1dbd57b5fa240bda1b8" +
+			"85eee733f6a13e5df33ce4b33c45dee4" +
+			"4bceeb8f646f4c55001706275e85e800" +
+			"587c4df568d094393e4834afd0805046",
+	)
+	dst := make([]byte, len(testPT))
+	NewCTRACPKM(newKuznechik, testKey, iv, 32).XORKeyStream(dst, testPT)
+	if bytes.Compare(dst, ct) != 0 {
+		t.FailNow()
+	}
+	s := NewCTRACPKM(newKuznechik, testKey, iv, 32)
+	for i := 0; i < len(dst); i++ {
+		s.XORKeyStream(dst[i:i+1], dst[i:i+1])
+	}
+	if bytes.Compare(dst, testPT) != 0 {
+		t.FailNow()
+	}
+}
+
+// Compare with ordinary CTR started from the proper counter under each
+// section's key.
+func TestCTRACPKMSections(t *testing.T) {
+	key := make([]byte, 32)
+	for i := 0; i < len(key); i++ {
+		key[i] = byte(i)
+	}
+	iv := []byte{0xff, 0xff, 0xff, 0xfe}
+	const section = 16
+	const sections = 5
+	pt := make([]byte, section*sections)
+	got := make([]byte, len(pt))
+	NewCTRACPKM(newMagma, key, iv, section).XORKeyStream(got, pt)
+
+	ctr := big.NewInt(0).SetBytes(append(iv, 0, 0, 0, 0))
+	mod := big.NewInt(0).Lsh(big.NewInt(1), 64)
+	b := newMagma(key)
+	for i := 0; i < sections; i++ {
+		if i > 0 {
+			d := make([]byte, 32)
+			for j := 0; j < len(d); j++ {
+				d[j] = byte(0x80 + j)
+			}
+			for j := 0; j < len(d); j += 8 {
+				b.Encrypt(d[j:j+8], d[j:j+8])
+			}
+			b = newMagma(d)
+		}
+		ctrRaw := make([]byte, 8)
+		ctrBytes := ctr.Bytes()
+		copy(ctrRaw[8-len(ctrBytes):], ctrBytes)
+		want := make([]byte, section)
+		cipher.NewCTR(b, ctrRaw).XORKeyStream(want, want)
+		if bytes.Compare(got[i*section:(i+1)*section], want) != 0 {
+			t.Fatal(i)
+		}
+		ctr.Add(ctr, big.NewInt(section/8))
+		ctr.Mod(ctr, mod)
+	}
+}
+
+type acpkmWindow struct {
+	offset   int
+	expected string
+}
+
+func checkACPKMOutput(t *testing.T, name string, got []byte, windows []acpkmWindow, sum string) {
+	for _, w := range windows {
+		expected, _ := hex.DecodeString(w.expected)
+		if bytes.Compare(got[w.offset:w.offset+len(expected)], expected) != 0 {
+			t.Fatal(name, "differs at", w.offset)
+		}
+	}
+	digest := sha256.Sum256(got)
+	expected, _ := hex.DecodeString(sum)
+	if bytes.Compare(digest[:], expected) != 0 {
+		t.Fatal(name, "differs")
+	}
+}
+
+// Keystreams made by GnuTLS 3.7.9 KUZNYECHIK-CTR-ACPKM and
+// MAGMA-CTR-ACPKM ciphers, that have 4096 and 1024 bytes sections,
+// with 8899...cdef key: parts around the first key change and SHA-256
+// of 8192 bytes.
+func TestCTRACPKMGnuTLS(t *testing.T) {
+	got := make([]byte, 8192)
+	iv, _ := hex.DecodeString("1234567890abcef0")
+	NewCTRACPKM(newKuznechik, testKey, iv, 4096).XORKeyStream(got, got)
+	checkACPKMOutput(t, "Kuznechik", got, []acpkmWindow{
+		{0, "e0b7ebfa9468a6db2a95826efb17383085ffc500b2f4582a7ba54e08f0ab21ee"},
+		{4080, "40e1468b9e5e964cdb817223bcf2714fb0ec5b8e9e458d83452cd257d02cc417"},
+	}, "e2ddb4e2c2e819da4de65199dfbae9892b9c7c99f109cf592571fc58e5766c3e")
+	got = make([]byte, 8192)
+	NewCTRACPKM(newMagma, testKey, iv[:4], 1024).XORKeyStream(got, got)
+	checkACPKMOutput(t, "Magma", got, []acpkmWindow{
+		{0, "3b9a2eaabe783bab970fd90806c10d62c05cf934aec7e9d5aa943d2a4fce8761"},
+		{1016, "7acf94d6decabb30c848c0880267bcbbfe74442af5056bba11620c56ef9b80fe"},
+	}, "92f914c75c9943df1f9d3428ff9d5a3d12d2dd916d66076a053c52bece9e5d4c")
+}
+
+// GnuTLS CTR-ACPKM keystreams with all-ones IV, as ACPKM-Master
+// defines it, with the same section sizes: 12288 bytes for Kuznechik,
+// 4096 for Magma. Both span several key changes.
+func TestACPKMMasterGnuTLS(t *testing.T) {
+	key := append([]byte{}, testKey...)
+	got := ACPKMMaster(newKuznechik, key, 4096, 12288)
+	checkACPKMOutput(t, "Kuznechik", got, []acpkmWindow{
+		{0, "0cabf1f2efbc4ac16048df1a24c605b2c0d1673d7586a8ec0dd42c45a4f95bae"},
+		{4080, "c0c8691167218c8a18436517b6999687a6417f9fb9ea436a7077d9503beb075b"},
+		{8176, "345eb12f8c1197790256dbf6b4f9ffacb3632a5e948ada6a3e1a851fc7bbfa2a"},
+	}, "f7bfc8c8c58441b88f75c1fdfc17b724a6402eec253ace5a78b85be1289eea8a")
+	got = ACPKMMaster(newMagma, key, 1024, 4096)
+	checkACPKMOutput(t, "Magma", got, []acpkmWindow{
+		{0, "0df2f5273da328932ac49d81d36b2558a50dbf9bbcac74a614b2ccb2f1cbcd8a"},
+		{1016, "f44b24c331c721424061a6a8ccff9b3d91e5de6d80ac32bdf4fd3ad7af8bccde"},
+		{2040, "42d07c2ba29770806160c33eb0c8bb1b4d7896ce6b0618d2b169442c144901b7"},
+	}, "78c222a366a6c7097399730c22707e76f74054537917703bf4e7dd28f066dd88")
+	if bytes.Compare(key, testKey) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestCTRACPKMInvalidSection(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.FailNow()
+		}
+	}()
+	NewCTRACPKM(newKuznechik, testKey, make([]byte, 8), 24)
+}
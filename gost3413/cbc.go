@@ -0,0 +1,66 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"crypto/cipher"
+)
+
+type cbc struct {
+	b       cipher.Block
+	r       *register
+	tmp     []byte
+	encrypt bool
+}
+
+// Cipher Block Chaining mode. iv is z*n bits long, z >= 1: each block
+// is chained with the ciphertext produced z blocks earlier. With z = 1
+// it is the same as crypto/cipher's CBC.
+func NewCBCEncrypter(b cipher.Block, iv []byte) cipher.BlockMode {
+	blockSize := b.BlockSize()
+	return &cbc{b, newRegister(blockSize, iv), make([]byte, blockSize), true}
+}
+
+func NewCBCDecrypter(b cipher.Block, iv []byte) cipher.BlockMode {
+	blockSize := b.BlockSize()
+	return &cbc{b, newRegister(blockSize, iv), make([]byte, blockSize), false}
+}
+
+func (c *cbc) BlockSize() int {
+	return c.b.BlockSize()
+}
+
+func (c *cbc) CryptBlocks(dst, src []byte) {
+	blockSize := c.b.BlockSize()
+	if len(src)%blockSize != 0 {
+		panic("input not full blocks")
+	}
+	if len(dst) < len(src) {
+		panic("output smaller than input")
+	}
+	for i := 0; i < len(src); i += blockSize {
+		if c.encrypt {
+			xor(c.tmp, src[i:i+blockSize], c.r.head())
+			c.b.Encrypt(dst[i:i+blockSize], c.tmp)
+			c.r.shift(dst[i : i+blockSize])
+		} else {
+			copy(c.tmp, src[i:i+blockSize])
+			c.b.Decrypt(dst[i:i+blockSize], c.tmp)
+			xor(dst[i:i+blockSize], dst[i:i+blockSize], c.r.head())
+			c.r.shift(c.tmp)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"testing/quick"
+)
+
+func TestCBCVector(t *testing.T) {
+	ct, _ := hex.DecodeString(
+		"689972d4a085fa4d90e52e3d6d7dcc27" +
+			"2826e661b478eca6af1e8e448d5ea5ac" +
+			"fe7babf1e91999e85640e8b0f49d90d0" +
+			"167688065a895c631a2d9a1560b63970",
+	)
+	c := testCipher()
+	dst := make([]byte, len(testPT))
+	NewCBCEncrypter(c, testIV).CryptBlocks(dst, testPT)
+	if bytes.Compare(dst, ct) != 0 {
+		t.FailNow()
+	}
+	d := NewCBCDecrypter(c, testIV)
+	d.CryptBlocks(dst[:16], dst[:16])
+	d.CryptBlocks(dst[16:], dst[16:])
+	if bytes.Compare(dst, testPT) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestCBCSingleBlockIV(t *testing.T) {
+	c := testCipher()
+	iv := testIV[:c.BlockSize()]
+	f := func(data []byte) bool {
+		data = data[:len(data)/c.BlockSize()*c.BlockSize()]
+		our := make([]byte, len(data))
+		their := make([]byte, len(data))
+		NewCBCEncrypter(c, iv).CryptBlocks(our, data)
+		cipher.NewCBCEncrypter(c, iv).CryptBlocks(their, data)
+		return bytes.Compare(our, their) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCBCInvalidIV(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.FailNow()
+		}
+	}()
+	iv := make([]byte, 15)
+	rand.Read(iv)
+	NewCBCEncrypter(testCipher(), iv)
+}
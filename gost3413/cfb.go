@@ -0,0 +1,81 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"crypto/cipher"
+)
+
+type cfb struct {
+	b       cipher.Block
+	r       *register
+	gamma   []byte
+	blk     []byte
+	used    int
+	encrypt bool
+}
+
+// Cipher Feedback mode with the full block feedback (s = n). iv is z*n
+// bits long, z >= 1. With z = 1 it is the same as crypto/cipher's CFB.
+func NewCFBEncrypter(b cipher.Block, iv []byte) cipher.Stream {
+	return newCFB(b, iv, true)
+}
+
+func NewCFBDecrypter(b cipher.Block, iv []byte) cipher.Stream {
+	return newCFB(b, iv, false)
+}
+
+func newCFB(b cipher.Block, iv []byte, encrypt bool) *cfb {
+	blockSize := b.BlockSize()
+	return &cfb{
+		b:       b,
+		r:       newRegister(blockSize, iv),
+		gamma:   make([]byte, blockSize),
+		blk:     make([]byte, blockSize),
+		used:    blockSize,
+		encrypt: encrypt,
+	}
+}
+
+func (c *cfb) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("output smaller than input")
+	}
+	var n int
+	for len(src) > 0 {
+		if c.used == len(c.gamma) {
+			c.b.Encrypt(c.gamma, c.r.head())
+			c.used = 0
+		}
+		n = len(c.gamma) - c.used
+		if n > len(src) {
+			n = len(src)
+		}
+		if c.encrypt {
+			xor(dst, src[:n], c.gamma[c.used:])
+			copy(c.blk[c.used:], dst[:n])
+		} else {
+			copy(c.blk[c.used:], src[:n])
+			xor(dst, src[:n], c.gamma[c.used:])
+		}
+		c.used += n
+		if c.used == len(c.gamma) {
+			c.r.shift(c.blk)
+		}
+		dst = dst[n:]
+		src = src[n:]
+	}
+}
@@ -0,0 +1,65 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/hex"
+	"testing"
+	"testing/quick"
+)
+
+func TestCFBVector(t *testing.T) {
+	ct, _ := hex.DecodeString(
+		"81800a59b1842b24ff1f795e897abd95" +
+			"ed5b47a7048cfab48fb521369d9326bf" +
+			"79f2a8eb5cc68d38842d264e97a238b5" +
+			"4ffebecd4e922de6c75bd9dd44fbf4d1",
+	)
+	c := testCipher()
+	dst := make([]byte, len(testPT))
+	NewCFBEncrypter(c, testIV).XORKeyStream(dst, testPT)
+	if bytes.Compare(dst, ct) != 0 {
+		t.FailNow()
+	}
+	s := NewCFBDecrypter(c, testIV)
+	s.XORKeyStream(dst[:5], dst[:5])
+	s.XORKeyStream(dst[5:40], dst[5:40])
+	s.XORKeyStream(dst[40:], dst[40:])
+	if bytes.Compare(dst, testPT) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestCFBSingleBlockIV(t *testing.T) {
+	c := testCipher()
+	iv := testIV[:c.BlockSize()]
+	f := func(data []byte) bool {
+		our := make([]byte, len(data))
+		their := make([]byte, len(data))
+		NewCFBEncrypter(c, iv).XORKeyStream(our, data)
+		cipher.NewCFBEncrypter(c, iv).XORKeyStream(their, data)
+		if bytes.Compare(our, their) != 0 {
+			return false
+		}
+		NewCFBDecrypter(c, iv).XORKeyStream(our, our)
+		return bytes.Compare(our, data) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,34 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"crypto/cipher"
+)
+
+// Counter mode with the full block gamma (s = n). iv is half of the
+// block size long: initial counter value is iv||0...0, incremented
+// modulo 2^n after each block. That is exactly crypto/cipher's CTR
+// with the zero-padded iv.
+func NewCTR(b cipher.Block, iv []byte) cipher.Stream {
+	blockSize := b.BlockSize()
+	if len(iv) != blockSize/2 {
+		panic("iv length is not equal to half of blocksize")
+	}
+	ctr := make([]byte, blockSize)
+	copy(ctr, iv)
+	return cipher.NewCTR(b, ctr)
+}
@@ -0,0 +1,45 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestCTRVector(t *testing.T) {
+	iv, _ := hex.DecodeString("1234567890abcef0")
+	ct, _ := hex.DecodeString(
+		"f195d8bec10ed1dbd57b5fa240bda1b8" +
+			"85eee733f6a13e5df33ce4b33c45dee4" +
+			"a5eae88be6356ed3d5e877f13564a3a5" +
+			"cb91fab1f20cbab6d1c6d15820bdba73",
+	)
+	c := testCipher()
+	dst := make([]byte, len(testPT))
+	NewCTR(c, iv).XORKeyStream(dst, testPT)
+	if bytes.Compare(dst, ct) != 0 {
+		t.FailNow()
+	}
+	s := NewCTR(c, iv)
+	for i := 0; i < len(dst); i++ {
+		s.XORKeyStream(dst[i:i+1], dst[i:i+1])
+	}
+	if bytes.Compare(dst, testPT) != 0 {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,56 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"crypto/cipher"
+)
+
+type ecb struct {
+	b       cipher.Block
+	encrypt bool
+}
+
+// Electronic Codebook mode. It is not provided by crypto/cipher, but
+// 34.13-2015 defines it.
+func NewECBEncrypter(b cipher.Block) cipher.BlockMode {
+	return &ecb{b, true}
+}
+
+func NewECBDecrypter(b cipher.Block) cipher.BlockMode {
+	return &ecb{b, false}
+}
+
+func (e *ecb) BlockSize() int {
+	return e.b.BlockSize()
+}
+
+func (e *ecb) CryptBlocks(dst, src []byte) {
+	blockSize := e.b.BlockSize()
+	if len(src)%blockSize != 0 {
+		panic("input not full blocks")
+	}
+	if len(dst) < len(src) {
+		panic("output smaller than input")
+	}
+	for i := 0; i < len(src); i += blockSize {
+		if e.encrypt {
+			e.b.Encrypt(dst[i:i+blockSize], src[i:i+blockSize])
+		} else {
+			e.b.Decrypt(dst[i:i+blockSize], src[i:i+blockSize])
+		}
+	}
+}
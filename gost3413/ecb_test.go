@@ -0,0 +1,41 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestECBVector(t *testing.T) {
+	ct, _ := hex.DecodeString(
+		"7f679d90bebc24305a468d42b9d4edcd" +
+			"b429912c6e0032f9285452d76718d08b" +
+			"f0ca33549d247ceef3f5a5313bd4b157" +
+			"d0b09ccde830b9eb3a02c4c5aa8ada98",
+	)
+	c := testCipher()
+	dst := make([]byte, len(testPT))
+	NewECBEncrypter(c).CryptBlocks(dst, testPT)
+	if bytes.Compare(dst, ct) != 0 {
+		t.FailNow()
+	}
+	NewECBDecrypter(c).CryptBlocks(dst, dst)
+	if bytes.Compare(dst, testPT) != 0 {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,127 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"crypto/cipher"
+	"errors"
+)
+
+// MAC (OMAC1, CMAC-alike) from GOST R 34.13-2015. It satisfies
+// hash.Hash.
+type MAC struct {
+	b       cipher.Block
+	size    int
+	k1      []byte
+	k2      []byte
+	prev    []byte
+	buf     []byte
+	tmp     []byte
+	tagSize int
+}
+
+// Make subkey from the previous one: shift left and conditionally xor
+// with Rb constant.
+func subkey(dst, src []byte) {
+	rb := byte(0x87)
+	if len(src) == 8 {
+		rb = 0x1b
+	}
+	msb := src[0] & 0x80
+	for i := 0; i < len(src)-1; i++ {
+		dst[i] = src[i]<<1 | src[i+1]>>7
+	}
+	dst[len(src)-1] = src[len(src)-1] << 1
+	if msb != 0 {
+		dst[len(src)-1] ^= rb
+	}
+}
+
+// Create MAC with 64 or 128 bit block cipher and tag size in bytes,
+// between 1 and the block size.
+func NewMAC(b cipher.Block, tagSize int) (*MAC, error) {
+	blockSize := b.BlockSize()
+	if !(blockSize == 8 || blockSize == 16) {
+		return nil, errors.New("gogost/gost3413: only 64/128 blocksizes allowed")
+	}
+	if tagSize < 1 || tagSize > blockSize {
+		return nil, errors.New("gogost/gost3413: invalid tag size")
+	}
+	m := MAC{
+		b:       b,
+		size:    blockSize,
+		k1:      make([]byte, blockSize),
+		k2:      make([]byte, blockSize),
+		prev:    make([]byte, blockSize),
+		buf:     make([]byte, 0, blockSize),
+		tmp:     make([]byte, blockSize),
+		tagSize: tagSize,
+	}
+	b.Encrypt(m.k1, m.k1)
+	subkey(m.k1, m.k1)
+	subkey(m.k2, m.k1)
+	return &m, nil
+}
+
+func (m *MAC) Reset() {
+	for i := 0; i < m.size; i++ {
+		m.prev[i] = 0
+	}
+	m.buf = m.buf[:0]
+}
+
+func (m *MAC) BlockSize() int {
+	return m.size
+}
+
+func (m *MAC) Size() int {
+	return m.tagSize
+}
+
+func (m *MAC) Write(b []byte) (int, error) {
+	n := len(b)
+	var free int
+	for len(b) > 0 {
+		// Last block is kept in buffer till Sum, as it is processed
+		// differently
+		if len(m.buf) == m.size {
+			xor(m.tmp, m.prev, m.buf)
+			m.b.Encrypt(m.prev, m.tmp)
+			m.buf = m.buf[:0]
+		}
+		free = m.size - len(m.buf)
+		if free > len(b) {
+			free = len(b)
+		}
+		m.buf = append(m.buf, b[:free]...)
+		b = b[free:]
+	}
+	return n, nil
+}
+
+func (m *MAC) Sum(b []byte) []byte {
+	blk := make([]byte, m.size)
+	copy(blk, m.buf)
+	k := m.k1
+	if len(m.buf) != m.size {
+		blk[len(m.buf)] = 0x80
+		k = m.k2
+	}
+	xor(blk, blk, m.prev)
+	xor(blk, blk, k)
+	m.b.Encrypt(blk, blk)
+	return append(b, blk[:m.tagSize]...)
+}
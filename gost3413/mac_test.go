@@ -0,0 +1,114 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"bytes"
+	"hash"
+	"testing"
+	"testing/quick"
+
+	"go.cypherpunks.ru/gogost/v5/gost341264"
+)
+
+func TestMACInterface(t *testing.T) {
+	m, err := NewMAC(testCipher(), 8)
+	if err != nil {
+		t.FailNow()
+	}
+	var _ hash.Hash = m
+}
+
+// GOST R 34.13-2015 Appendix A.1.6
+func TestMACVectorKuznechik(t *testing.T) {
+	m, err := NewMAC(testCipher(), 8)
+	if err != nil {
+		t.FailNow()
+	}
+	if bytes.Compare(m.k1, []byte{
+		0x29, 0x7d, 0x82, 0xbc, 0x4d, 0x39, 0xe3, 0xca,
+		0x0d, 0xe0, 0x57, 0x32, 0x98, 0x15, 0x1d, 0xc7,
+	}) != 0 {
+		t.FailNow()
+	}
+	if bytes.Compare(m.k2, []byte{
+		0x52, 0xfb, 0x05, 0x78, 0x9a, 0x73, 0xc7, 0x94,
+		0x1b, 0xc0, 0xae, 0x65, 0x30, 0x2a, 0x3b, 0x8e,
+	}) != 0 {
+		t.FailNow()
+	}
+	m.Write(testPT)
+	if bytes.Compare(m.Sum(nil), []byte{
+		0x33, 0x6f, 0x4d, 0x29, 0x60, 0x59, 0xfb, 0xe3,
+	}) != 0 {
+		t.FailNow()
+	}
+}
+
+// GOST R 34.13-2015 Appendix A.2.6
+func TestMACVectorMagma(t *testing.T) {
+	c := gost341264.NewCipher([]byte{
+		0xff, 0xee, 0xdd, 0xcc, 0xbb, 0xaa, 0x99, 0x88,
+		0x77, 0x66, 0x55, 0x44, 0x33, 0x22, 0x11, 0x00,
+		0xf0, 0xf1, 0xf2, 0xf3, 0xf4, 0xf5, 0xf6, 0xf7,
+		0xf8, 0xf9, 0xfa, 0xfb, 0xfc, 0xfd, 0xfe, 0xff,
+	})
+	m, err := NewMAC(c, 4)
+	if err != nil {
+		t.FailNow()
+	}
+	m.Write([]byte{
+		0x92, 0xde, 0xf0, 0x6b, 0x3c, 0x13, 0x0a, 0x59,
+		0xdb, 0x54, 0xc7, 0x04, 0xf8, 0x18, 0x9d, 0x20,
+		0x4a, 0x98, 0xfb, 0x2e, 0x67, 0xa8, 0x02, 0x4c,
+		0x89, 0x12, 0x40, 0x9b, 0x17, 0xb5, 0x7e, 0x41,
+	})
+	if bytes.Compare(m.Sum(nil), []byte{0x15, 0x4e, 0x72, 0x10}) != 0 {
+		t.FailNow()
+	}
+}
+
+func TestMACInvalidTagSize(t *testing.T) {
+	if _, err := NewMAC(testCipher(), 0); err == nil {
+		t.FailNow()
+	}
+	if _, err := NewMAC(testCipher(), 17); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestMACRandom(t *testing.T) {
+	m, err := NewMAC(testCipher(), 16)
+	if err != nil {
+		t.FailNow()
+	}
+	f := func(data []byte) bool {
+		m.Reset()
+		m.Write(data)
+		tag1 := m.Sum(nil)
+		if bytes.Compare(m.Sum(nil), tag1) != 0 {
+			return false
+		}
+		m.Reset()
+		for _, b := range data {
+			m.Write([]byte{b})
+		}
+		return bytes.Compare(m.Sum(nil), tag1) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,93 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"crypto/cipher"
+)
+
+// Shift register R of m = z*n bits, used by OFB, CBC and CFB modes.
+// Its most significant block is the head (the one to be processed
+// next), and a new block is appended to the tail.
+type register struct {
+	buf []byte
+	n   int
+}
+
+func newRegister(blockSize int, iv []byte) *register {
+	if len(iv) == 0 || len(iv)%blockSize != 0 {
+		panic("iv length is not multiple of blocksize")
+	}
+	buf := make([]byte, len(iv))
+	copy(buf, iv)
+	return &register{buf, blockSize}
+}
+
+func (r *register) head() []byte {
+	return r.buf[:r.n]
+}
+
+func (r *register) shift(blk []byte) {
+	copy(r.buf, r.buf[r.n:])
+	copy(r.buf[len(r.buf)-r.n:], blk)
+}
+
+func xor(dst, src1, src2 []byte) {
+	for i := 0; i < len(src1); i++ {
+		dst[i] = src1[i] ^ src2[i]
+	}
+}
+
+type ofb struct {
+	b     cipher.Block
+	r     *register
+	gamma []byte
+	used  int
+}
+
+// Output Feedback mode with the full block gamma (s = n). iv is z*n
+// bits long, z >= 1.
+func NewOFB(b cipher.Block, iv []byte) cipher.Stream {
+	blockSize := b.BlockSize()
+	return &ofb{
+		b:     b,
+		r:     newRegister(blockSize, iv),
+		gamma: make([]byte, blockSize),
+		used:  blockSize,
+	}
+}
+
+func (o *ofb) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("output smaller than input")
+	}
+	var n int
+	for len(src) > 0 {
+		if o.used == len(o.gamma) {
+			o.b.Encrypt(o.gamma, o.r.head())
+			o.r.shift(o.gamma)
+			o.used = 0
+		}
+		n = len(o.gamma) - o.used
+		if n > len(src) {
+			n = len(src)
+		}
+		xor(dst, src[:n], o.gamma[o.used:])
+		o.used += n
+		dst = dst[n:]
+		src = src[n:]
+	}
+}
@@ -0,0 +1,44 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestOFBVector(t *testing.T) {
+	ct, _ := hex.DecodeString(
+		"81800a59b1842b24ff1f795e897abd95" +
+			"ed5b47a7048cfab48fb521369d9326bf" +
+			"66a257ac3ca0b8b1c80fe7fc10288a13" +
+			"203ebbc066138660a0292243f6903150",
+	)
+	c := testCipher()
+	dst := make([]byte, len(testPT))
+	NewOFB(c, testIV).XORKeyStream(dst, testPT)
+	if bytes.Compare(dst, ct) != 0 {
+		t.FailNow()
+	}
+	s := NewOFB(c, testIV)
+	s.XORKeyStream(dst[:5], dst[:5])
+	s.XORKeyStream(dst[5:40], dst[5:40])
+	s.XORKeyStream(dst[40:], dst[40:])
+	if bytes.Compare(dst, testPT) != 0 {
+		t.FailNow()
+	}
+}
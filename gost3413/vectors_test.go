@@ -0,0 +1,51 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gost3413
+
+import (
+	"go.cypherpunks.ru/gogost/v5/gost3412128"
+)
+
+// GOST R 34.13-2015 Appendix A.1 data for Kuznechik.
+var (
+	testKey = []byte{
+		0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff,
+		0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77,
+		0xfe, 0xdc, 0xba, 0x98, 0x76, 0x54, 0x32, 0x10,
+		0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef,
+	}
+	testPT = []byte{
+		0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x00,
+		0xff, 0xee, 0xdd, 0xcc, 0xbb, 0xaa, 0x99, 0x88,
+		0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77,
+		0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xee, 0xff, 0x0a,
+		0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88,
+		0x99, 0xaa, 0xbb, 0xcc, 0xee, 0xff, 0x0a, 0x00,
+		0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99,
+		0xaa, 0xbb, 0xcc, 0xee, 0xff, 0x0a, 0x00, 0x11,
+	}
+	// Two blocks long iv for OFB, CBC and CFB
+	testIV = []byte{
+		0x12, 0x34, 0x56, 0x78, 0x90, 0xab, 0xce, 0xf0,
+		0xa1, 0xb2, 0xc3, 0xd4, 0xe5, 0xf0, 0x01, 0x12,
+		0x23, 0x34, 0x45, 0x56, 0x67, 0x78, 0x89, 0x90,
+		0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19,
+	}
+)
+
+func testCipher() *gost3412128.Cipher {
+	return gost3412128.NewCipher(testKey)
+}
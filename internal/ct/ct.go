@@ -0,0 +1,26 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Constant-time comparison of MACs and authentication tags.
+package ct
+
+import "crypto/subtle"
+
+// Are a and b equal. Time depends only on their lengths, not on the
+// contents or the position of the first mismatch. Lengths are treated
+// as public: different ones return false immediately.
+func Eq(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
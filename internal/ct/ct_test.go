@@ -0,0 +1,67 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ct
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestEq(t *testing.T) {
+	if !Eq(nil, nil) || !Eq([]byte{1, 2}, []byte{1, 2}) {
+		t.FailNow()
+	}
+	if Eq([]byte{1, 2}, []byte{1, 3}) || Eq([]byte{1, 2}, []byte{1}) {
+		t.FailNow()
+	}
+}
+
+func medianDuration(a, b []byte) time.Duration {
+	const runs = 15
+	const iterations = 2000
+	durations := make([]time.Duration, 0, runs)
+	for i := 0; i < runs; i++ {
+		started := time.Now()
+		for j := 0; j < iterations; j++ {
+			Eq(a, b)
+		}
+		durations = append(durations, time.Since(started))
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	return durations[runs/2]
+}
+
+// Best-effort check that comparison time does not depend on the
+// mismatch position. Early-exit comparison over such a long buffer
+// differs by orders of magnitude, so the bound is generous.
+func TestEqTimingInvariant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	a := make([]byte, 4096)
+	first := make([]byte, len(a))
+	first[0] = 1
+	last := make([]byte, len(a))
+	last[len(last)-1] = 1
+	dFirst := medianDuration(a, first)
+	dLast := medianDuration(a, last)
+	if dLast > 3*dFirst || dFirst > 3*dLast {
+		t.Fatalf("first mismatch: %s, last mismatch: %s", dFirst, dLast)
+	}
+}
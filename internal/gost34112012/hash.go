@@ -372,6 +372,14 @@ func (h *Hash) Sum(in []byte) []byte {
 	return append(in, hsh...)
 }
 
+// Digest of the data written so far, as if Sum were called now. State
+// is not changed, so writing can be continued and digests of the
+// stream's prefixes can be taken. That is the same as Sum(nil), as
+// hash.Hash requires, but makes the intention explicit.
+func (h *Hash) Checkpoint() []byte {
+	return h.Sum(nil)
+}
+
 func (h *Hash) add512bit(chk, data []byte) []byte {
 	var ss uint16
 	for i := 0; i < BlockSize; i++ {
@@ -230,3 +230,24 @@ func BenchmarkHash(b *testing.B) {
 		h.Sum(nil)
 	}
 }
+
+func TestCheckpoint(t *testing.T) {
+	data := make([]byte, 3*BlockSize+17)
+	rand.Read(data)
+	for _, size := range []int{32, 64} {
+		h := New(size)
+		for i := 0; i <= len(data); i++ {
+			if i > 0 {
+				h.Write(data[i-1 : i])
+			}
+			ref := New(size)
+			ref.Write(data[:i])
+			if bytes.Compare(h.Checkpoint(), ref.Sum(nil)) != 0 {
+				t.Fatal(size, i)
+			}
+		}
+		if bytes.Compare(h.Sum(nil), h.Checkpoint()) != 0 {
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mgm
+
+import (
+	"container/list"
+	"crypto/cipher"
+	"errors"
+	"sync"
+)
+
+var ErrNonceReuse = errors.New("gogost/mgm: nonce reuse detected")
+
+// MGM wrapper remembering nonces used for sealing. Nonce reuse with MGM
+// reveals plaintexts XOR and authentication key, so NonceGuard
+// refuses to seal with the nonce it has already seen. Only the last
+// capacity nonces are kept, older ones are forgotten, and nothing is
+// kept across restarts: that is only a defense-in-depth best-effort
+// check, not a guarantee. Unique nonces must still be ensured by
+// the caller. Opening is not affected.
+type NonceGuard struct {
+	aead     cipher.AEAD
+	capacity int
+	m        sync.Mutex
+	seen     map[string]*list.Element
+	order    *list.List
+}
+
+// Create MGM AEAD remembering up to capacity last used nonces.
+func NewMGMWithNonceGuard(cipher cipher.Block, tagSize, capacity int) (*NonceGuard, error) {
+	if capacity < 1 {
+		return nil, errors.New("gogost/mgm: invalid nonce guard capacity")
+	}
+	aead, err := NewMGM(cipher, tagSize)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceGuard{
+		aead:     aead,
+		capacity: capacity,
+		seen:     make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}, nil
+}
+
+func (g *NonceGuard) NonceSize() int {
+	return g.aead.NonceSize()
+}
+
+func (g *NonceGuard) Overhead() int {
+	return g.aead.Overhead()
+}
+
+// Remember the nonce, returning ErrNonceReuse if it is already known.
+func (g *NonceGuard) remember(nonce []byte) error {
+	g.m.Lock()
+	defer g.m.Unlock()
+	key := string(nonce)
+	if _, ok := g.seen[key]; ok {
+		return ErrNonceReuse
+	}
+	g.seen[key] = g.order.PushBack(key)
+	if g.order.Len() > g.capacity {
+		oldest := g.order.Front()
+		g.order.Remove(oldest)
+		delete(g.seen, oldest.Value.(string))
+	}
+	return nil
+}
+
+// Seal like cipher.AEAD does, but return ErrNonceReuse if nonce was
+// recently used.
+func (g *NonceGuard) SealChecked(dst, nonce, plaintext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != g.aead.NonceSize() {
+		panic("nonce length must be equal to cipher's blocksize")
+	}
+	if err := g.remember(nonce); err != nil {
+		return nil, err
+	}
+	return g.aead.Seal(dst, nonce, plaintext, additionalData), nil
+}
+
+// cipher.AEAD's Seal can not return an error, so it panics with
+// ErrNonceReuse if nonce was recently used. Use SealChecked to get
+// an error instead.
+func (g *NonceGuard) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	out, err := g.SealChecked(dst, nonce, plaintext, additionalData)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func (g *NonceGuard) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	return g.aead.Open(dst, nonce, ciphertext, additionalData)
+}
@@ -0,0 +1,74 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mgm
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost3412128"
+)
+
+func TestNonceGuard(t *testing.T) {
+	key := make([]byte, gost3412128.KeySize)
+	g, err := NewMGMWithNonceGuard(gost3412128.NewCipher(key), 16, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var _ cipher.AEAD = g
+	nonce1 := make([]byte, 16)
+	nonce2 := make([]byte, 16)
+	nonce2[15] = 2
+	nonce3 := make([]byte, 16)
+	nonce3[15] = 3
+	pt := []byte("plaintext")
+	ct, err := g.SealChecked(nil, nonce1, pt, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = g.SealChecked(nil, nonce1, pt, nil); err != ErrNonceReuse {
+		t.Fatal("nonce reuse is not detected")
+	}
+	func() {
+		defer func() {
+			if recover() != ErrNonceReuse {
+				t.Fatal("Seal did not panic on nonce reuse")
+			}
+		}()
+		g.Seal(nil, nonce1, pt, nil)
+	}()
+	got, err := g.Open(nil, nonce1, ct, nil)
+	if err != nil || bytes.Compare(got, pt) != 0 {
+		t.Fatal("open failed")
+	}
+	if _, err = g.SealChecked(nil, nonce2, pt, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = g.SealChecked(nil, nonce3, pt, nil); err != nil {
+		t.Fatal(err)
+	}
+	// nonce1 is evicted, as only two last nonces are kept
+	if _, err = g.SealChecked(nil, nonce1, pt, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = g.SealChecked(nil, nonce3, pt, nil); err != ErrNonceReuse {
+		t.Fatal("nonce reuse is not detected")
+	}
+	if _, err = NewMGMWithNonceGuard(gost3412128.NewCipher(key), 16, 0); err == nil {
+		t.Fatal("zero capacity accepted")
+	}
+}
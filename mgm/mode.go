@@ -18,9 +18,10 @@ package mgm
 
 import (
 	"crypto/cipher"
-	"crypto/hmac"
 	"encoding/binary"
 	"errors"
+
+	"go.cypherpunks.ru/gogost/v5/internal/ct"
 )
 
 type Mul interface {
@@ -217,12 +218,12 @@ func (mgm *MGM) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, err
 		panic("ciphertext is too big")
 	}
 	ret, out := sliceForAppend(dst, len(ciphertext)-mgm.TagSize)
-	ct := ciphertext[:len(ciphertext)-mgm.TagSize]
+	text := ciphertext[:len(ciphertext)-mgm.TagSize]
 	copy(mgm.icn, nonce)
-	mgm.auth(mgm.sum, ct, additionalData)
-	if !hmac.Equal(mgm.sum[:mgm.TagSize], ciphertext[len(ciphertext)-mgm.TagSize:]) {
+	mgm.auth(mgm.sum, text, additionalData)
+	if !ct.Eq(mgm.sum[:mgm.TagSize], ciphertext[len(ciphertext)-mgm.TagSize:]) {
 		return nil, errors.New("gogost/mgm: invalid authentication tag")
 	}
-	mgm.crypt(out, ct)
+	mgm.crypt(out, text)
 	return ret, nil
 }
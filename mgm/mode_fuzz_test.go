@@ -0,0 +1,65 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build go1.18
+// +build go1.18
+
+package mgm
+
+import (
+	"bytes"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost3412128"
+	"go.cypherpunks.ru/gogost/v5/gost341264"
+)
+
+func FuzzSealOpen(f *testing.F) {
+	f.Add([]byte("nonce"), []byte("plaintext"), []byte("additional data"), false)
+	f.Add([]byte{}, []byte{}, []byte{0x01}, true)
+	key := make([]byte, gost3412128.KeySize)
+	aead128, err := NewMGM(gost3412128.NewCipher(key), gost3412128.BlockSize)
+	if err != nil {
+		f.FailNow()
+	}
+	aead64, err := NewMGM(gost341264.NewCipher(key), 4)
+	if err != nil {
+		f.FailNow()
+	}
+	f.Fuzz(func(t *testing.T, nonceRaw, plaintext, additionalData []byte, use64 bool) {
+		if len(plaintext) == 0 && len(additionalData) == 0 {
+			return
+		}
+		aead := aead128
+		if use64 {
+			aead = aead64
+		}
+		nonce := make([]byte, aead.NonceSize())
+		copy(nonce, nonceRaw)
+		nonce[0] &= 0x7F
+		sealed := aead.Seal(nil, nonce, plaintext, additionalData)
+		if len(sealed) != len(plaintext)+aead.Overhead() {
+			t.FailNow()
+		}
+		pt, err := aead.Open(nil, nonce, sealed, additionalData)
+		if err != nil || bytes.Compare(pt, plaintext) != 0 {
+			t.FailNow()
+		}
+		sealed[len(sealed)-1] ^= 0x01
+		if _, err = aead.Open(nil, nonce, sealed, additionalData); err == nil {
+			t.FailNow()
+		}
+	})
+}
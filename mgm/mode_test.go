@@ -147,6 +147,50 @@ func TestSymmetric(t *testing.T) {
 	)
 }
 
+func TestTampered(t *testing.T) {
+	key := make([]byte, gost3412128.KeySize)
+	rand.Read(key)
+	aead, err := NewMGM(gost3412128.NewCipher(key), gost3412128.BlockSize)
+	if err != nil {
+		t.FailNow()
+	}
+	nonce := make([]byte, aead.NonceSize())
+	rand.Read(nonce)
+	nonce[0] &= 0x7F
+	f := func(plaintext, additionalData []byte, idx uint16) bool {
+		if len(plaintext) == 0 && len(additionalData) == 0 {
+			return true
+		}
+		sealed := aead.Seal(nil, nonce, plaintext, additionalData)
+		i := int(idx) % (len(sealed) + len(additionalData))
+		if i < len(sealed) {
+			sealed[i] ^= 0x01
+		} else {
+			additionalData[i-len(sealed)] ^= 0x01
+		}
+		_, err := aead.Open(nil, nonce, sealed, additionalData)
+		return err != nil
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNonceHigherBit(t *testing.T) {
+	aead, err := NewMGM(gost341264.NewCipher(make([]byte, gost341264.KeySize)), 8)
+	if err != nil {
+		t.FailNow()
+	}
+	defer func() {
+		if recover() == nil {
+			t.FailNow()
+		}
+	}()
+	nonce := make([]byte, aead.NonceSize())
+	nonce[0] = 0x80
+	aead.Seal(nil, nonce, []byte("data"), nil)
+}
+
 func BenchmarkMGM64(b *testing.B) {
 	key := make([]byte, gost341264.KeySize)
 	if _, err := io.ReadFull(rand.Reader, key); err != nil {
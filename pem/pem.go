@@ -0,0 +1,94 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// PEM encoding of GOST R 34.10 keys.
+//
+// Private keys are stored as "PRIVATE KEY" blocks with PKCS#8 structure,
+// public keys as "PUBLIC KEY" blocks with SubjectPublicKeyInfo, both as
+// produced by gost3410.MarshalPKCS8PrivateKey and
+// gost3410.MarshalPKIXPublicKey.
+package pem
+
+import (
+	"encoding/pem"
+	"errors"
+
+	"go.cypherpunks.ru/gogost/v5/gost3410"
+)
+
+const (
+	PrivateKeyType = "PRIVATE KEY"
+	PublicKeyType  = "PUBLIC KEY"
+)
+
+// Encode private key to PEM "PRIVATE KEY" block.
+func EncodePrivateKeyPEM(prv *gost3410.PrivateKey) ([]byte, error) {
+	der, err := gost3410.MarshalPKCS8PrivateKey(prv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PrivateKeyType, Bytes: der}), nil
+}
+
+// Decode first PEM block, that must be "PRIVATE KEY" one. Returns the
+// rest of data after it.
+func DecodePrivateKeyPEM(data []byte) (*gost3410.PrivateKey, []byte, error) {
+	der, rest, err := decode(data, PrivateKeyType)
+	if err != nil {
+		return nil, nil, err
+	}
+	prv, err := gost3410.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return prv, rest, nil
+}
+
+// Encode public key to PEM "PUBLIC KEY" block.
+func EncodePublicKeyPEM(pub *gost3410.PublicKey) ([]byte, error) {
+	der, err := gost3410.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PublicKeyType, Bytes: der}), nil
+}
+
+// Decode first PEM block, that must be "PUBLIC KEY" one. Returns the
+// rest of data after it.
+func DecodePublicKeyPEM(data []byte) (*gost3410.PublicKey, []byte, error) {
+	der, rest, err := decode(data, PublicKeyType)
+	if err != nil {
+		return nil, nil, err
+	}
+	pub, err := gost3410.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pub, rest, nil
+}
+
+func decode(data []byte, typ string) ([]byte, []byte, error) {
+	block, rest := pem.Decode(data)
+	if block == nil {
+		return nil, nil, errors.New("gogost/pem: no PEM block found")
+	}
+	if block.Type != typ {
+		return nil, nil, errors.New("gogost/pem: unexpected block type " + block.Type)
+	}
+	if len(block.Headers) != 0 {
+		return nil, nil, errors.New("gogost/pem: unexpected headers")
+	}
+	return block.Bytes, rest, nil
+}
@@ -0,0 +1,89 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package pem
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost3410"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, c := range []*gost3410.Curve{
+		gost3410.CurveIdGostR34102001CryptoProAParamSet(),
+		gost3410.CurveIdtc26gost341012256paramSetA(),
+		gost3410.CurveIdtc26gost341012512paramSetC(),
+	} {
+		prv, err := gost3410.GenPrivateKey(c, rand.Reader)
+		if err != nil {
+			t.FailNow()
+		}
+		pub, err := prv.PublicKey()
+		if err != nil {
+			t.FailNow()
+		}
+		var buf bytes.Buffer
+		data, err := EncodePrivateKeyPEM(prv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf.Write(data)
+		data, err = EncodePublicKeyPEM(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf.Write(data)
+
+		prvGot, rest, err := DecodePrivateKeyPEM(buf.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prvGot.Key.Cmp(prv.Key) != 0 || !prvGot.C.Equal(c) {
+			t.FailNow()
+		}
+		pubGot, rest, err := DecodePublicKeyPEM(rest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rest) != 0 {
+			t.FailNow()
+		}
+		if pubGot.X.Cmp(pub.X) != 0 || pubGot.Y.Cmp(pub.Y) != 0 || !pubGot.C.Equal(c) {
+			t.FailNow()
+		}
+	}
+}
+
+func TestWrongType(t *testing.T) {
+	prv, err := gost3410.GenPrivateKey(
+		gost3410.CurveIdtc26gost341012256paramSetA(), rand.Reader,
+	)
+	if err != nil {
+		t.FailNow()
+	}
+	data, err := EncodePrivateKeyPEM(prv)
+	if err != nil {
+		t.FailNow()
+	}
+	if _, _, err = DecodePublicKeyPEM(data); err == nil {
+		t.FailNow()
+	}
+	if _, _, err = DecodePrivateKeyPEM([]byte("garbage")); err == nil {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,195 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gogost
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"go.cypherpunks.ru/gogost/v5/gost3410"
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+	"go.cypherpunks.ru/gogost/v5/gost3412128"
+	"go.cypherpunks.ru/gogost/v5/gost341264"
+)
+
+// Known answer test: run must return expected value.
+type selfTest struct {
+	name     string
+	run      func() ([]byte, error)
+	expected string
+}
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+var streebogM1 = []byte("012345678901234567890123456789012345678901234567890123456789012")
+
+var selfTests = []selfTest{
+	{
+		// GOST R 34.11-2012 M1 example
+		name: "Streebog-256",
+		run: func() ([]byte, error) {
+			h := gost34112012256.New()
+			h.Write(streebogM1)
+			return h.Sum(nil), nil
+		},
+		expected: "9d151eefd8590b89daa6ba6cb74af927" +
+			"5dd051026bb149a452fd84e5e57b5500",
+	},
+	{
+		name: "Streebog-512",
+		run: func() ([]byte, error) {
+			h := gost34112012512.New()
+			h.Write(streebogM1)
+			return h.Sum(nil), nil
+		},
+		expected: "1b54d01a4af5b9d5cc3d86d68d285462" +
+			"b19abc2475222f35c085122be4ba1ffa" +
+			"00ad30f8767b3a82384c6574f024c311" +
+			"e2a481332b08ef7f41797891c1646f48",
+	},
+	{
+		// GOST R 34.12-2015 appendix A.1
+		name: "Kuznyechik",
+		run: func() ([]byte, error) {
+			c := gost3412128.NewCipher(mustHex(
+				"8899aabbccddeeff0011223344556677" +
+					"fedcba98765432100123456789abcdef",
+			))
+			pt := mustHex("1122334455667700ffeeddccbbaa9988")
+			ct := make([]byte, gost3412128.BlockSize)
+			c.Encrypt(ct, pt)
+			c.Decrypt(pt, ct)
+			if !bytes.Equal(pt, mustHex("1122334455667700ffeeddccbbaa9988")) {
+				return nil, errors.New("decryption mismatch")
+			}
+			return ct, nil
+		},
+		expected: "7f679d90bebc24305a468d42b9d4edcd",
+	},
+	{
+		// GOST R 34.12-2015 appendix A.2
+		name: "Magma",
+		run: func() ([]byte, error) {
+			c := gost341264.NewCipher(mustHex(
+				"ffeeddccbbaa99887766554433221100" +
+					"f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff",
+			))
+			pt := mustHex("fedcba9876543210")
+			ct := make([]byte, gost341264.BlockSize)
+			c.Encrypt(ct, pt)
+			c.Decrypt(pt, ct)
+			if !bytes.Equal(pt, mustHex("fedcba9876543210")) {
+				return nil, errors.New("decryption mismatch")
+			}
+			return ct, nil
+		},
+		expected: "4ee901e5c2d8ca3d",
+	},
+	{
+		// GOST R 34.10-2012 appendix example 1, s||r output
+		name: "GOST R 34.10-2012 sign/verify",
+		run: func() ([]byte, error) {
+			c := gost3410.CurveIdGostR34102001TestParamSet()
+			prv, err := gost3410.NewPrivateKeyBigEndian(c, mustHex(
+				"7a929ade789bb9be10ed359dd39a72c1"+
+					"1b60961f49397eee1d19ce9891ec3b28",
+			))
+			if err != nil {
+				return nil, err
+			}
+			digest := mustHex(
+				"2dfbc1b372d89a1188c09c52e0eec61f" +
+					"ce52032ab1022e8e67ece6672b043ee5",
+			)
+			sign, err := prv.SignDigest(digest, bytes.NewReader(mustHex(
+				"77105c9b20bcd3122823c8cf6fcc7b95"+
+					"6de33814e95b7fe64fed924594dceab3",
+			)))
+			if err != nil {
+				return nil, err
+			}
+			pub, err := prv.PublicKey()
+			if err != nil {
+				return nil, err
+			}
+			valid, err := pub.VerifyDigest(digest, sign)
+			if err != nil {
+				return nil, err
+			}
+			if !valid {
+				return nil, errors.New("signature is not valid")
+			}
+			return sign, nil
+		},
+		expected: "01456c64ba4642a1653c235a98a60249" +
+			"bcd6d3f746b631df928014f6c5bf9c40" +
+			"41aa28d2f1ab148280cd9ed56feda419" +
+			"74053554a42767b83ad043fd39dc0493",
+	},
+	{
+		// RFC 7836 appendix A.2 VKO_GOSTR3410_2012_256
+		name: "VKO GOST R 34.10-2012 256",
+		run: func() ([]byte, error) {
+			c := gost3410.CurveIdtc26gost341012512paramSetA()
+			prv, err := gost3410.NewPrivateKey(c, mustHex(
+				"c990ecd972fce84ec4db022778f50fcac726f46708384b8d458304962d7147f8"+
+					"c2db41cef22c90b102f2968404f9b9be6d47c79692d81826b32b8daca43cb667",
+			))
+			if err != nil {
+				return nil, err
+			}
+			pub, err := gost3410.NewPublicKey(c, mustHex(
+				"192fe183b9713a077253c72c8735de2ea42a3dbc66ea317838b65fa32523cd5e"+
+					"fca974eda7c863f4954d1147f1f2b25c395fce1c129175e876d132e94ed5a651"+
+					"04883b414c9b592ec4dc84826f07d0b6d9006dda176ce48c391e3f97d102e03b"+
+					"b598bf132a228a45f7201aba08fc524a2d77e43a362ab022ad4028f75bde3b79",
+			))
+			if err != nil {
+				return nil, err
+			}
+			return prv.KEK2012256(pub, gost3410.NewUKM(mustHex("1d80603c8544c727")))
+		},
+		expected: "c9a9a77320e2cc559ed72dce6f47e219" +
+			"2ccea95fa648670582c054c0ef36c221",
+	},
+}
+
+// Run known answer tests of Streebog-256/512, Kuznyechik, Magma,
+// GOST R 34.10-2012 signing and verification and VKO key agreement,
+// like power-on self-tests in regulated environments do. It is never
+// called automatically: applications call it during the startup.
+// Error names the first failed test.
+func SelfTest() error {
+	for _, test := range selfTests {
+		got, err := test.run()
+		if err != nil {
+			return fmt.Errorf("gogost: %s self-test failed: %v", test.name, err)
+		}
+		if !bytes.Equal(got, mustHex(test.expected)) {
+			return fmt.Errorf("gogost: %s self-test failed: unexpected result", test.name)
+		}
+	}
+	return nil
+}
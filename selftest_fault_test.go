@@ -0,0 +1,44 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build gogost_fault
+// +build gogost_fault
+
+package gogost
+
+import "testing"
+
+// Corrupt every primitive in turn by flipping a bit of its output and
+// check that SelfTest notices that. Run with "-tags gogost_fault".
+func TestSelfTestFaultInjection(t *testing.T) {
+	for i := range selfTests {
+		orig := selfTests[i].run
+		selfTests[i].run = func() ([]byte, error) {
+			out, err := orig()
+			if err == nil {
+				out[len(out)-1] ^= 0x01
+			}
+			return out, err
+		}
+		err := SelfTest()
+		selfTests[i].run = orig
+		if err == nil {
+			t.Fatalf("corrupted %s is not detected", selfTests[i].name)
+		}
+	}
+	if err := SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,297 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package x509
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+	"time"
+
+	"go.cypherpunks.ru/gogost/v5/gost3410"
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+)
+
+var (
+	oidExtensionSubjectKeyId     = asn1.ObjectIdentifier{2, 5, 29, 14}
+	oidExtensionKeyUsage         = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtensionSubjectAltName   = asn1.ObjectIdentifier{2, 5, 29, 17}
+	oidExtensionBasicConstraints = asn1.ObjectIdentifier{2, 5, 29, 19}
+	oidExtensionAuthorityKeyId   = asn1.ObjectIdentifier{2, 5, 29, 35}
+	oidExtensionExtendedKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 37}
+
+	extKeyUsageOIDs = map[x509.ExtKeyUsage]asn1.ObjectIdentifier{
+		x509.ExtKeyUsageAny:             {2, 5, 29, 37, 0},
+		x509.ExtKeyUsageServerAuth:      {1, 3, 6, 1, 5, 5, 7, 3, 1},
+		x509.ExtKeyUsageClientAuth:      {1, 3, 6, 1, 5, 5, 7, 3, 2},
+		x509.ExtKeyUsageCodeSigning:     {1, 3, 6, 1, 5, 5, 7, 3, 3},
+		x509.ExtKeyUsageEmailProtection: {1, 3, 6, 1, 5, 5, 7, 3, 4},
+		x509.ExtKeyUsageTimeStamping:    {1, 3, 6, 1, 5, 5, 7, 3, 8},
+		x509.ExtKeyUsageOCSPSigning:     {1, 3, 6, 1, 5, 5, 7, 3, 9},
+	}
+)
+
+type validity struct {
+	NotBefore, NotAfter time.Time
+}
+
+type tbsCertificate struct {
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           validity
+	Subject            asn1.RawValue
+	PublicKey          asn1.RawValue
+	Extensions         []pkix.Extension `asn1:"omitempty,optional,explicit,tag:3"`
+}
+
+type basicConstraints struct {
+	IsCA       bool `asn1:"optional"`
+	MaxPathLen int  `asn1:"optional,default:-1"`
+}
+
+type authKeyId struct {
+	Id []byte `asn1:"optional,tag:0"`
+}
+
+// Get signature algorithm OID for the curve: 34.10-2012 with the
+// Streebog of the same size.
+func signatureAlgorithmForCurve(c *gost3410.Curve) (asn1.ObjectIdentifier, func() hash.Hash) {
+	if c.PointSize() == 64 {
+		return OIDtc26SignWithDigestGost341012512, gost34112012512.New
+	}
+	return OIDtc26SignWithDigestGost341012256, gost34112012256.New
+}
+
+func rawName(raw []byte, name pkix.Name) ([]byte, error) {
+	if len(raw) > 0 {
+		return raw, nil
+	}
+	return asn1.Marshal(name.ToRDNSequence())
+}
+
+func marshalKeyUsage(ku x509.KeyUsage) ([]byte, error) {
+	var a [2]byte
+	a[0] = reverseBitsInAByte(byte(ku))
+	a[1] = reverseBitsInAByte(byte(ku >> 8))
+	l := 1
+	if a[1] != 0 {
+		l = 2
+	}
+	bitString := a[:l]
+	return asn1.Marshal(asn1.BitString{
+		Bytes:     bitString,
+		BitLength: asn1BitLength(bitString),
+	})
+}
+
+func reverseBitsInAByte(in byte) byte {
+	b1 := in>>4 | in<<4
+	b2 := b1>>2&0x33 | b1<<2&0xcc
+	return b2>>1&0x55 | b2<<1&0xaa
+}
+
+func asn1BitLength(bitString []byte) int {
+	bitLen := len(bitString) * 8
+	for i := range bitString {
+		b := bitString[len(bitString)-i-1]
+		for bit := uint(0); bit < 8; bit++ {
+			if (b>>bit)&1 == 1 {
+				return bitLen
+			}
+			bitLen--
+		}
+	}
+	return 0
+}
+
+func marshalSANs(template *x509.Certificate) ([]byte, error) {
+	var names []asn1.RawValue
+	for _, name := range template.DNSNames {
+		names = append(names, asn1.RawValue{Tag: 2, Class: 2, Bytes: []byte(name)})
+	}
+	for _, email := range template.EmailAddresses {
+		names = append(names, asn1.RawValue{Tag: 1, Class: 2, Bytes: []byte(email)})
+	}
+	for _, rawIP := range template.IPAddresses {
+		ip := rawIP.To4()
+		if ip == nil {
+			ip = rawIP
+		}
+		names = append(names, asn1.RawValue{Tag: 7, Class: 2, Bytes: ip})
+	}
+	return asn1.Marshal(names)
+}
+
+func extensions(
+	template *x509.Certificate,
+	authorityKeyId, subjectKeyId []byte,
+) ([]pkix.Extension, error) {
+	var exts []pkix.Extension
+	add := func(id asn1.ObjectIdentifier, critical bool, v interface{}) error {
+		value, err := asn1.Marshal(v)
+		if err == nil {
+			exts = append(exts, pkix.Extension{Id: id, Critical: critical, Value: value})
+		}
+		return err
+	}
+	if template.KeyUsage != 0 {
+		value, err := marshalKeyUsage(template.KeyUsage)
+		if err != nil {
+			return nil, err
+		}
+		exts = append(exts, pkix.Extension{
+			Id: oidExtensionKeyUsage, Critical: true, Value: value,
+		})
+	}
+	if len(template.ExtKeyUsage) > 0 || len(template.UnknownExtKeyUsage) > 0 {
+		var oids []asn1.ObjectIdentifier
+		for _, u := range template.ExtKeyUsage {
+			oid, ok := extKeyUsageOIDs[u]
+			if !ok {
+				return nil, errors.New("gogost/x509: unsupported extended key usage")
+			}
+			oids = append(oids, oid)
+		}
+		oids = append(oids, template.UnknownExtKeyUsage...)
+		if err := add(oidExtensionExtendedKeyUsage, false, oids); err != nil {
+			return nil, err
+		}
+	}
+	if template.BasicConstraintsValid {
+		maxPathLen := template.MaxPathLen
+		if maxPathLen == 0 && !template.MaxPathLenZero {
+			maxPathLen = -1
+		}
+		if err := add(oidExtensionBasicConstraints, true, basicConstraints{
+			template.IsCA, maxPathLen,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if len(subjectKeyId) > 0 {
+		if err := add(oidExtensionSubjectKeyId, false, subjectKeyId); err != nil {
+			return nil, err
+		}
+	}
+	if len(authorityKeyId) > 0 {
+		if err := add(oidExtensionAuthorityKeyId, false, authKeyId{authorityKeyId}); err != nil {
+			return nil, err
+		}
+	}
+	if len(template.DNSNames) > 0 ||
+		len(template.EmailAddresses) > 0 ||
+		len(template.IPAddresses) > 0 {
+		value, err := marshalSANs(template)
+		if err != nil {
+			return nil, err
+		}
+		exts = append(exts, pkix.Extension{Id: oidExtensionSubjectAltName, Value: value})
+	}
+	return append(exts, template.ExtraExtensions...), nil
+}
+
+// Create DER encoded X.509 v3 certificate, based on the template, with
+// pub public key, signed by prv, that belongs to parent. Certificate is
+// self-signed if parent equals to template. Signature algorithm is
+// 34.10-2012 with Streebog of prv's curve size. Only subset of template
+// fields is supported: SerialNumber, Subject, NotBefore, NotAfter,
+// KeyUsage, ExtKeyUsage, UnknownExtKeyUsage, BasicConstraintsValid,
+// IsCA, MaxPathLen, MaxPathLenZero, SubjectKeyId, AuthorityKeyId,
+// DNSNames, EmailAddresses, IPAddresses and ExtraExtensions.
+func CreateCertificate(
+	rand io.Reader,
+	template, parent *x509.Certificate,
+	pub *gost3410.PublicKey,
+	prv *gost3410.PrivateKey,
+) ([]byte, error) {
+	if template.SerialNumber == nil || template.SerialNumber.Sign() <= 0 {
+		return nil, errors.New("gogost/x509: serial number must be positive")
+	}
+	if parentPub, ok := parent.PublicKey.(*gost3410.PublicKey); ok {
+		prvPub, err := prv.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		if !prvPub.Equal(parentPub) {
+			return nil, errors.New("gogost/x509: private key does not match parent's public key")
+		}
+	}
+	spki, err := gost3410.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := rawName(parent.RawSubject, parent.Subject)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := rawName(template.RawSubject, template.Subject)
+	if err != nil {
+		return nil, err
+	}
+	authorityKeyId := template.AuthorityKeyId
+	if !bytes.Equal(issuer, subject) && len(parent.SubjectKeyId) > 0 {
+		authorityKeyId = parent.SubjectKeyId
+	}
+	subjectKeyId := template.SubjectKeyId
+	if len(subjectKeyId) == 0 && template.IsCA {
+		key, err := asn1.Marshal(pub.Raw())
+		if err != nil {
+			return nil, err
+		}
+		h := sha1.Sum(key)
+		subjectKeyId = h[:]
+	}
+	exts, err := extensions(template, authorityKeyId, subjectKeyId)
+	if err != nil {
+		return nil, err
+	}
+	algoOID, newHash := signatureAlgorithmForCurve(prv.C)
+	algo := pkix.AlgorithmIdentifier{Algorithm: algoOID}
+	tbs, err := asn1.Marshal(tbsCertificate{
+		Version:            2,
+		SerialNumber:       template.SerialNumber,
+		SignatureAlgorithm: algo,
+		Issuer:             asn1.RawValue{FullBytes: issuer},
+		Validity:           validity{template.NotBefore.UTC(), template.NotAfter.UTC()},
+		Subject:            asn1.RawValue{FullBytes: subject},
+		PublicKey:          asn1.RawValue{FullBytes: spki},
+		Extensions:         exts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	signature, err := prv.SignDigest(reversedDigest(newHash, tbs), rand)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(certificate{
+		TBSCertificate:     asn1.RawValue{FullBytes: tbs},
+		SignatureAlgorithm: algo,
+		SignatureValue: asn1.BitString{
+			Bytes:     signature,
+			BitLength: 8 * len(signature),
+		},
+	})
+}
@@ -0,0 +1,140 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package x509
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"go.cypherpunks.ru/gogost/v5/gost3410"
+)
+
+func createCA(t *testing.T, c *gost3410.Curve) (*x509.Certificate, *gost3410.PrivateKey) {
+	prv, err := gost3410.GenPrivateKey(c, rand.Reader)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := CreateCertificate(rand.Reader, template, template, pub, prv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = CheckSelfSigned(cert); err != nil {
+		t.Fatal(err)
+	}
+	if !cert.PublicKey.(*gost3410.PublicKey).Equal(pub) {
+		t.FailNow()
+	}
+	return cert, prv
+}
+
+func TestCreateSelfSigned(t *testing.T) {
+	for _, c := range []*gost3410.Curve{
+		gost3410.CurveIdtc26gost341012256paramSetA(),
+		gost3410.CurveIdtc26gost341012512paramSetC(),
+	} {
+		ca, _ := createCA(t, c)
+		if !ca.IsCA || ca.MaxPathLen != 0 || !ca.MaxPathLenZero {
+			t.FailNow()
+		}
+		if ca.KeyUsage != x509.KeyUsageCertSign|x509.KeyUsageCRLSign {
+			t.FailNow()
+		}
+		if ca.Subject.CommonName != "CA" || len(ca.SubjectKeyId) == 0 {
+			t.FailNow()
+		}
+	}
+}
+
+func TestCreateSignedByCA(t *testing.T) {
+	ca, caPrv := createCA(t, gost3410.CurveIdtc26gost341012512paramSetA())
+	prv, err := gost3410.GenPrivateKey(
+		gost3410.CurveIdtc26gost341012256paramSetB(), rand.Reader,
+	)
+	if err != nil {
+		t.FailNow()
+	}
+	pub, err := prv.PublicKey()
+	if err != nil {
+		t.FailNow()
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"example.com"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := CreateCertificate(rand.Reader, template, ca, pub, caPrv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = CheckSignatureFrom(cert, ca); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cert.AuthorityKeyId, ca.SubjectKeyId) {
+		t.FailNow()
+	}
+	if cert.Issuer.CommonName != "CA" || cert.IsCA {
+		t.FailNow()
+	}
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != "example.com" {
+		t.FailNow()
+	}
+	if len(cert.IPAddresses) != 1 || !cert.IPAddresses[0].Equal(net.IPv4(127, 0, 0, 1)) {
+		t.FailNow()
+	}
+	if len(cert.ExtKeyUsage) != 1 || cert.ExtKeyUsage[0] != x509.ExtKeyUsageServerAuth {
+		t.FailNow()
+	}
+	if err = CheckSelfSigned(cert); err == nil {
+		t.FailNow()
+	}
+
+	if _, err = CreateCertificate(rand.Reader, template, ca, pub, prv); err == nil {
+		t.FailNow()
+	}
+}
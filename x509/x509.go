@@ -0,0 +1,160 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// X.509 certificates with GOST R 34.10 public keys and signatures
+// (RFC 4491, RFC 9215).
+//
+// crypto/x509 parses such certificates, but knows nothing about their
+// public key and signature algorithms. ParseCertificate fills
+// Certificate.PublicKey with *gost3410.PublicKey and CheckSignatureFrom
+// verifies GOST signatures.
+package x509
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"hash"
+
+	"go.cypherpunks.ru/gogost/v5/gost28147"
+	"go.cypherpunks.ru/gogost/v5/gost3410"
+	"go.cypherpunks.ru/gogost/v5/gost34112012256"
+	"go.cypherpunks.ru/gogost/v5/gost34112012512"
+	"go.cypherpunks.ru/gogost/v5/gost341194"
+)
+
+var (
+	// Signature algorithms
+	OIDGostR341194WithGostR34102001    = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 3}
+	OIDtc26SignWithDigestGost341012256 = gost3410.OIDtc26SignWithDigestGost341012256
+	OIDtc26SignWithDigestGost341012512 = gost3410.OIDtc26SignWithDigestGost341012512
+)
+
+func newGost341194() hash.Hash {
+	return gost341194.New(&gost28147.SboxIdGostR341194CryptoProParamSet)
+}
+
+var signatureAlgorithms = []struct {
+	oid       asn1.ObjectIdentifier
+	pointSize int
+	newHash   func() hash.Hash
+}{
+	{OIDGostR341194WithGostR34102001, 32, newGost341194},
+	{OIDtc26SignWithDigestGost341012256, 32, gost34112012256.New},
+	{OIDtc26SignWithDigestGost341012512, 64, gost34112012512.New},
+}
+
+type certificate struct {
+	TBSCertificate     asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+// Parse DER encoded certificate. If it has GOST R 34.10 public key, then
+// its PublicKey field is *gost3410.PublicKey.
+func ParseCertificate(der []byte) (*x509.Certificate, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	if cert.PublicKeyAlgorithm != x509.UnknownPublicKeyAlgorithm {
+		return cert, nil
+	}
+	pub, err := gost3410.ParsePKIXPublicKey(cert.RawSubjectPublicKeyInfo)
+	if err != nil {
+		return nil, err
+	}
+	cert.PublicKey = pub
+	return cert, nil
+}
+
+// Get certificate's signature algorithm OID.
+func signatureAlgorithm(cert *x509.Certificate) (asn1.ObjectIdentifier, error) {
+	var c certificate
+	rest, err := asn1.Unmarshal(cert.Raw, &c)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("gogost/x509: trailing data after certificate")
+	}
+	if len(c.SignatureAlgorithm.Parameters.FullBytes) != 0 &&
+		!bytes.Equal(c.SignatureAlgorithm.Parameters.FullBytes, asn1.NullRawValue.FullBytes) {
+		return nil, errors.New("gogost/x509: unexpected signature algorithm parameters")
+	}
+	return c.SignatureAlgorithm.Algorithm, nil
+}
+
+// Hash data and reverse the digest, as RFC 4491 requires.
+func reversedDigest(newHash func() hash.Hash, data []byte) []byte {
+	h := newHash()
+	h.Write(data)
+	digest := h.Sum(nil)
+	for i, j := 0, len(digest)-1; i < j; i, j = i+1, j-1 {
+		digest[i], digest[j] = digest[j], digest[i]
+	}
+	return digest
+}
+
+// Verify GOST R 34.10 signature over data.
+func verify(pub *gost3410.PublicKey, algo asn1.ObjectIdentifier, data, signature []byte) error {
+	for _, known := range signatureAlgorithms {
+		if !known.oid.Equal(algo) {
+			continue
+		}
+		if pub.C.PointSize() != known.pointSize {
+			return errors.New("gogost/x509: signature algorithm does not match the key")
+		}
+		valid, err := pub.VerifyDigest(reversedDigest(known.newHash, data), signature)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errors.New("gogost/x509: invalid signature")
+		}
+		return nil
+	}
+	return x509.ErrUnsupportedAlgorithm
+}
+
+// Verify that cert's signature is valid signature from parent, that
+// must have GOST R 34.10 public key, be a CA and be cert's issuer.
+func CheckSignatureFrom(cert, parent *x509.Certificate) error {
+	if parent.Version == 3 && !(parent.BasicConstraintsValid && parent.IsCA) {
+		return x509.ConstraintViolationError{}
+	}
+	if parent.KeyUsage != 0 && parent.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return x509.ConstraintViolationError{}
+	}
+	if !bytes.Equal(cert.RawIssuer, parent.RawSubject) {
+		return errors.New("gogost/x509: issuer does not match parent's subject")
+	}
+	pub, ok := parent.PublicKey.(*gost3410.PublicKey)
+	if !ok {
+		return errors.New("gogost/x509: parent has no GOST R 34.10 public key")
+	}
+	algo, err := signatureAlgorithm(cert)
+	if err != nil {
+		return err
+	}
+	return verify(pub, algo, cert.RawTBSCertificate, cert.Signature)
+}
+
+// Verify that self-signed certificate is signed with its own key.
+func CheckSelfSigned(cert *x509.Certificate) error {
+	return CheckSignatureFrom(cert, cert)
+}
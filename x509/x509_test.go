@@ -0,0 +1,131 @@
+// GoGOST -- Pure Go GOST cryptographic functions library
+// Copyright (C) 2015-2022 Sergey Matveev <stargrave@stargrave.org>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3 of the License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package x509
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"testing"
+
+	"go.cypherpunks.ru/gogost/v5/gost3410"
+)
+
+func readCertificate(t *testing.T, path string) []byte {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		t.Fatal("no certificate in", path)
+	}
+	return block.Bytes
+}
+
+func TestParseSelfSigned(t *testing.T) {
+	for _, tc := range []struct {
+		path  string
+		curve *gost3410.Curve
+	}{
+		{"testdata/ca256.pem", gost3410.CurveIdtc26gost341012256paramSetB()},
+		{"testdata/ca512.pem", gost3410.CurveIdtc26gost341012512paramSetA()},
+	} {
+		cert, err := ParseCertificate(readCertificate(t, tc.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, ok := cert.PublicKey.(*gost3410.PublicKey)
+		if !ok {
+			t.Fatal("no GOST public key in", tc.path)
+		}
+		if !pub.C.Equal(tc.curve) {
+			t.Fatal("unexpected curve in", tc.path)
+		}
+		if !cert.IsCA {
+			t.FailNow()
+		}
+		if err = CheckSelfSigned(cert); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Self-signed CA certificates made by GnuTLS 3.7.9 (independent GOST
+// implementation): 34.10-2001 with 34.11-94 signature, 34.10-2012 with
+// 256- and 512-bit Streebog ones.
+func TestParseGnuTLS(t *testing.T) {
+	for _, tc := range []struct {
+		path  string
+		curve *gost3410.Curve
+	}{
+		{"testdata/gnutls-ca2001.pem", gost3410.CurveIdGostR34102001CryptoProAParamSet()},
+		{"testdata/gnutls-ca256.pem", gost3410.CurveIdtc26gost341012256paramSetB()},
+		{"testdata/gnutls-ca512.pem", gost3410.CurveIdtc26gost341012512paramSetA()},
+	} {
+		cert, err := ParseCertificate(readCertificate(t, tc.path))
+		if err != nil {
+			t.Fatal(tc.path, err)
+		}
+		pub, ok := cert.PublicKey.(*gost3410.PublicKey)
+		if !ok {
+			t.Fatal("no GOST public key in", tc.path)
+		}
+		if !pub.C.Equal(tc.curve) {
+			t.Fatal("unexpected curve in", tc.path)
+		}
+		if !cert.IsCA || cert.Subject.CommonName != cert.Issuer.CommonName {
+			t.FailNow()
+		}
+		if err = CheckSelfSigned(cert); err != nil {
+			t.Fatal(tc.path, err)
+		}
+		cert.Signature[len(cert.Signature)-1] ^= 0x01
+		if err = CheckSelfSigned(cert); err == nil {
+			t.Fatal(tc.path, "tampered signature is verified")
+		}
+	}
+}
+
+func TestTampered(t *testing.T) {
+	der := readCertificate(t, "testdata/ca256.pem")
+	cert, err := ParseCertificate(der)
+	if err != nil {
+		t.FailNow()
+	}
+	cert.Signature[0] ^= 0x01
+	if err = CheckSelfSigned(cert); err == nil {
+		t.FailNow()
+	}
+	cert.Signature[0] ^= 0x01
+	cert.RawTBSCertificate[len(cert.RawTBSCertificate)-1] ^= 0x01
+	if err = CheckSelfSigned(cert); err == nil {
+		t.FailNow()
+	}
+}
+
+func TestWrongParent(t *testing.T) {
+	cert256, err := ParseCertificate(readCertificate(t, "testdata/ca256.pem"))
+	if err != nil {
+		t.FailNow()
+	}
+	cert512, err := ParseCertificate(readCertificate(t, "testdata/ca512.pem"))
+	if err != nil {
+		t.FailNow()
+	}
+	if err = CheckSignatureFrom(cert256, cert512); err == nil {
+		t.FailNow()
+	}
+}